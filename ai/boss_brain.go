@@ -0,0 +1,167 @@
+package ai
+
+// BossPhase is one stage of a boss fight.
+type BossPhase struct {
+	Until       float32   // the phase lasts while HealthFraction is above this
+	Attacks     []Special // cycled in order
+	Telegraph   float32   // seconds of warning before each attack
+	Rest        float32   // seconds of plain chasing between attacks
+	SummonCount int       // minions per SpecialSummon
+}
+
+// BossConfig tunes a BossBrain.
+type BossConfig struct {
+	Phases         []BossPhase
+	MeleeRange     float32 // claw range while chasing, and contact range while charging
+	MeleeCooldown  float32
+	ChargeDuration float32 // seconds a charge lasts at most
+	SlamRadius     float32
+}
+
+// DefaultBossConfig is a three-phase fight: charge/slam, then minions join
+// in, then everything gets faster.
+func DefaultBossConfig() BossConfig {
+	return BossConfig{
+		Phases: []BossPhase{
+			{Until: 0.66, Attacks: []Special{SpecialCharge, SpecialSlam}, Telegraph: 1.2, Rest: 3},
+			{Until: 0.33, Attacks: []Special{SpecialSummon, SpecialCharge, SpecialSlam}, Telegraph: 1.0, Rest: 2.5, SummonCount: 2},
+			{Until: 0, Attacks: []Special{SpecialSlam, SpecialCharge, SpecialSummon, SpecialCharge}, Telegraph: 0.7, Rest: 1.5, SummonCount: 3},
+		},
+		MeleeRange:     90,
+		MeleeCooldown:  1.2,
+		ChargeDuration: 1.5,
+		SlamRadius:     220,
+	}
+}
+
+type bossStage int
+
+const (
+	bossResting bossStage = iota
+	bossTelegraphing
+	bossCharging
+)
+
+// BossBrain runs a phase-based pattern of telegraphed special attacks.
+// Phase changes interrupt whatever is going on and restart the pattern.
+type BossBrain struct {
+	Cfg BossConfig
+
+	stage     bossStage
+	timer     float32
+	attack    Special
+	next      int     // index into the phase's Attacks
+	chargeDir float32 // locked when the charge starts
+	chargeHit bool    // a charge only hurts once
+}
+
+func NewBossBrain(cfg BossConfig) *BossBrain {
+	return &BossBrain{Cfg: cfg, timer: cfg.Phases[0].Rest}
+}
+
+func (b *BossBrain) phaseFor(bb *Blackboard) int {
+	frac := bb.HealthFraction()
+	for i, p := range b.Cfg.Phases {
+		if frac > p.Until {
+			return i
+		}
+	}
+	return len(b.Cfg.Phases) - 1
+}
+
+func (b *BossBrain) Think(bb *Blackboard, dt float32) Decision {
+	var out Decision
+	if bb.Cooldown > 0 {
+		bb.Cooldown -= dt
+	}
+
+	// Phase transition: drop the current attack and start the new pattern
+	if p := b.phaseFor(bb); p != bb.Phase {
+		bb.Phase = p
+		b.stage = bossResting
+		b.next = 0
+		b.timer = 0.5
+	}
+	phase := b.Cfg.Phases[bb.Phase]
+
+	if !bb.TargetVisible && !bb.HasLastKnown {
+		out.Action = ActIdle
+		return out
+	}
+	target := bb.LastKnownPos
+	if bb.TargetVisible {
+		target = bb.TargetPos
+	}
+	dist := bb.DistanceToTarget()
+
+	b.timer -= dt
+	switch b.stage {
+	case bossResting:
+		// Lumber towards the player and claw if close enough
+		bb.FacingRight = target.X >= bb.Position.X
+		if dist <= b.Cfg.MeleeRange {
+			out.Action = ActAttack
+			if bb.Cooldown <= 0 {
+				out.Strike = true
+				bb.Cooldown = b.Cfg.MeleeCooldown
+			}
+		} else {
+			out.Action = ActChase
+			out.MoveDir = bb.dirTo(target.X)
+			out.HasGoal = true
+			out.Goal = target
+		}
+		if b.timer <= 0 {
+			b.attack = phase.Attacks[b.next%len(phase.Attacks)]
+			b.next++
+			b.stage = bossTelegraphing
+			b.timer = phase.Telegraph
+		}
+
+	case bossTelegraphing:
+		bb.FacingRight = target.X >= bb.Position.X
+		out.Action = ActWindUp
+		out.Special = b.attack
+		if b.timer > 0 {
+			break
+		}
+		out.Action = ActAttack
+		switch b.attack {
+		case SpecialCharge:
+			b.stage = bossCharging
+			b.timer = b.Cfg.ChargeDuration
+			b.chargeDir = bb.dirTo(target.X)
+			b.chargeHit = false
+			out.MoveDir = b.chargeDir
+		case SpecialSlam:
+			out.Strike = true
+			out.StrikeRadius = b.Cfg.SlamRadius
+			b.rest(phase)
+		case SpecialSummon:
+			out.Summon = phase.SummonCount
+			b.rest(phase)
+		}
+
+	case bossCharging:
+		out.Action = ActAttack
+		out.Special = SpecialCharge
+		out.MoveDir = b.chargeDir
+		bb.FacingRight = b.chargeDir > 0
+		if !b.chargeHit && dist <= b.Cfg.MeleeRange {
+			out.Strike = true
+			b.chargeHit = true
+		}
+		// Stop when time's up or we've overshot the player by a fair bit
+		overshot := (b.chargeDir > 0 && bb.Position.X > target.X+150) ||
+			(b.chargeDir < 0 && bb.Position.X < target.X-150)
+		if b.timer <= 0 || overshot {
+			b.rest(phase)
+		}
+	}
+	return out
+}
+
+func (b *BossBrain) rest(phase BossPhase) {
+	b.stage = bossResting
+	b.timer = phase.Rest
+}
@@ -0,0 +1,108 @@
+// Package ai holds the decision making for hostile NPCs.
+//
+// Nothing in here loads textures, plays sounds or reads the keyboard, so a
+// Brain can be ticked without a window: feed it a Blackboard, a Target and
+// whatever Stimuli happened this frame and look at the Decision it returns.
+package ai
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// Action is what a brain wants its body to be doing this tick.
+type Action int
+
+const (
+	ActIdle Action = iota
+	ActWander
+	ActInvestigate
+	ActChase
+	ActWindUp
+	ActAttack
+	ActFlee
+)
+
+// Decision is the output of one Think call. The body (e.g. gameobjects.Zombie)
+// turns it into movement, animation state and damage.
+type Decision struct {
+	Action  Action
+	MoveDir float32    // -1 = left, +1 = right, 0 = stand still
+	Strike  bool       // true only on the tick an attack actually lands
+	HasGoal bool       // true when the body should path towards Goal instead of just using MoveDir
+	Goal    rl.Vector2 // where we're trying to get to (same coordinates as Target.Position)
+
+	// Special attacks (bosses). With ActWindUp they mean "telegraphing", with
+	// ActAttack "executing".
+	Special      Special
+	StrikeRadius float32 // >0: Strike hits everything this close (slam); 0 = plain melee
+	Summon       int     // minions to summon this tick
+}
+
+// Special is a non-melee attack a brain can ask its body to perform.
+type Special int
+
+const (
+	SpecialNone Special = iota
+	SpecialCharge
+	SpecialSlam
+	SpecialSummon
+)
+
+// Brain is the pluggable part: behaviour trees, utility scorers, scripted
+// test brains... anything that can turn a Blackboard into a Decision.
+type Brain interface {
+	Think(bb *Blackboard, dt float32) Decision
+}
+
+// Blackboard is everything one agent knows about itself and the world.
+// Perception writes into it, the Brain reads (and keeps timers in) it.
+type Blackboard struct {
+	ID          int
+	Position    rl.Vector2
+	FacingRight bool
+	Health      float32
+	MaxHealth   float32
+
+	// ─── Perception results ───
+	TargetVisible bool
+	TargetPos     rl.Vector2 // only valid while TargetVisible
+	HasLastKnown  bool       // we saw or heard something and haven't forgotten it
+	LastKnownPos  rl.Vector2
+	TimeSinceSeen float32 // seconds since TargetVisible was last true
+
+	// ─── Brain bookkeeping ───
+	WanderDir   float32
+	WanderTimer float32
+	WindUpTimer float32 // >0 while an attack is being telegraphed
+	Cooldown    float32 // time left before the next attack may start
+	Fleeing     bool
+	Phase       int // boss phase (0 = first); unused by regular zombies
+
+	Horde *Horde // optional; nil means this agent acts alone
+}
+
+// HealthFraction returns Health/MaxHealth, treating a zero MaxHealth as full.
+func (bb *Blackboard) HealthFraction() float32 {
+	if bb.MaxHealth <= 0 {
+		return 1
+	}
+	return bb.Health / bb.MaxHealth
+}
+
+// DistanceToTarget is the distance to what we're currently hunting: the live
+// target if visible, otherwise the last known position.
+func (bb *Blackboard) DistanceToTarget() float32 {
+	if bb.TargetVisible {
+		return rl.Vector2Distance(bb.Position, bb.TargetPos)
+	}
+	if bb.HasLastKnown {
+		return rl.Vector2Distance(bb.Position, bb.LastKnownPos)
+	}
+	return -1
+}
+
+// dirTo returns -1/+1 for "which way do I walk to reach x".
+func (bb *Blackboard) dirTo(x float32) float32 {
+	if x < bb.Position.X {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,67 @@
+package ai
+
+// Status is the result of ticking a behaviour tree node.
+type Status int
+
+const (
+	Success Status = iota
+	Failure
+	Running
+)
+
+// Node is one node of a behaviour tree. Leaf nodes write into out.
+type Node interface {
+	Tick(bb *Blackboard, dt float32, out *Decision) Status
+}
+
+// Selector ticks children in order and stops at the first one that doesn't fail.
+type Selector []Node
+
+func (s Selector) Tick(bb *Blackboard, dt float32, out *Decision) Status {
+	for _, child := range s {
+		if st := child.Tick(bb, dt, out); st != Failure {
+			return st
+		}
+	}
+	return Failure
+}
+
+// Sequence ticks children in order and stops at the first one that doesn't succeed.
+type Sequence []Node
+
+func (s Sequence) Tick(bb *Blackboard, dt float32, out *Decision) Status {
+	for _, child := range s {
+		if st := child.Tick(bb, dt, out); st != Success {
+			return st
+		}
+	}
+	return Success
+}
+
+// Condition is a leaf that succeeds when the predicate holds.
+type Condition func(bb *Blackboard) bool
+
+func (c Condition) Tick(bb *Blackboard, dt float32, out *Decision) Status {
+	if c(bb) {
+		return Success
+	}
+	return Failure
+}
+
+// Task is a leaf that does the actual work.
+type Task func(bb *Blackboard, dt float32, out *Decision) Status
+
+func (t Task) Tick(bb *Blackboard, dt float32, out *Decision) Status {
+	return t(bb, dt, out)
+}
+
+// Tree adapts a root Node to the Brain interface.
+type Tree struct {
+	Root Node
+}
+
+func (t *Tree) Think(bb *Blackboard, dt float32) Decision {
+	var out Decision
+	t.Root.Tick(bb, dt, &out)
+	return out
+}
@@ -0,0 +1,75 @@
+package ai
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// Horde lets a group of agents coordinate: whoever spots the target shouts
+// about it, and only a few of them get to swing at once so the rest hang
+// back instead of stacking on the same pixel.
+type Horde struct {
+	MaxAttackers int     // how many members may be attacking at the same time
+	AlertRadius  float32 // how far a sighting is shared
+
+	members   []*Blackboard
+	attackers map[*Blackboard]bool
+}
+
+func NewHorde(maxAttackers int, alertRadius float32) *Horde {
+	return &Horde{
+		MaxAttackers: maxAttackers,
+		AlertRadius:  alertRadius,
+		attackers:    make(map[*Blackboard]bool),
+	}
+}
+
+// Join adds bb to the horde.
+func (h *Horde) Join(bb *Blackboard) {
+	bb.Horde = h
+	h.members = append(h.members, bb)
+}
+
+// Leave removes bb and frees any attack slot it was holding.
+func (h *Horde) Leave(bb *Blackboard) {
+	for i, m := range h.members {
+		if m == bb {
+			h.members = append(h.members[:i], h.members[i+1:]...)
+			break
+		}
+	}
+	delete(h.attackers, bb)
+	bb.Horde = nil
+}
+
+// Alert tells every member near `from` where the target was just seen.
+func (h *Horde) Alert(from *Blackboard, pos rl.Vector2) {
+	for _, m := range h.members {
+		if m == from || m.TargetVisible {
+			continue
+		}
+		if rl.Vector2Distance(m.Position, from.Position) <= h.AlertRadius {
+			m.LastKnownPos = pos
+			m.HasLastKnown = true
+			m.TimeSinceSeen = 0
+		}
+	}
+}
+
+// claimAttack reserves an attack slot for bb. Returns false if all slots are taken.
+func (h *Horde) claimAttack(bb *Blackboard) bool {
+	if h.attackers[bb] {
+		return true
+	}
+	if len(h.attackers) >= h.MaxAttackers {
+		return false
+	}
+	h.attackers[bb] = true
+	return true
+}
+
+// canAttack reports whether bb holds a slot or could claim one right now.
+func (h *Horde) canAttack(bb *Blackboard) bool {
+	return h.attackers[bb] || len(h.attackers) < h.MaxAttackers
+}
+
+func (h *Horde) releaseAttack(bb *Blackboard) {
+	delete(h.attackers, bb)
+}
@@ -0,0 +1,138 @@
+package ai
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// StimulusKind says what made a noise.
+type StimulusKind int
+
+const (
+	StimGunshot StimulusKind = iota
+	StimFootsteps
+	StimReload
+	StimExplosion
+	StimCritter // small animals scurrying about; not the target, but worth a look
+)
+
+// Stimulus is something an agent might hear this frame. Radius is how far
+// away it can be heard at all.
+type Stimulus struct {
+	Kind     StimulusKind
+	Position rl.Vector2
+	Radius   float32
+}
+
+// Target is the thing being hunted (normally the player).
+type Target struct {
+	Position   rl.Vector2
+	Visibility float32 // 1 = fully visible, 0 = invisible; scales sight range
+	Provoked   bool    // hit the agent recently; hunted ahead of nearer targets
+}
+
+// Senses describes how well an agent perceives the world.
+type Senses struct {
+	SightRange     float32 // how far it can see straight ahead
+	SightHalfAngle float32 // half-width of the sight cone, in degrees
+	TouchRange     float32 // anything this close is noticed regardless of facing
+	Hearing        float32 // multiplier on stimulus radius (1 = normal)
+	Memory         float32 // seconds before an unseen target is forgotten
+}
+
+// DefaultSenses are tuned for the basic walker zombie.
+func DefaultSenses() Senses {
+	return Senses{
+		SightRange:     300,
+		SightHalfAngle: 35,
+		TouchRange:     60,
+		Hearing:        1,
+		Memory:         4,
+	}
+}
+
+// ChooseTarget picks which of several targets (the player, the companion...)
+// an agent hunts: one that provoked it, else the nearest it can see, else the
+// nearest. Returns the target's index, or -1 if there are none.
+func (s Senses) ChooseTarget(bb *Blackboard, targets []Target) int {
+	best, bestRank := -1, -1
+	var bestDist float32
+	for i, t := range targets {
+		rank := 0
+		switch {
+		case t.Provoked:
+			rank = 2
+		case s.canSee(bb, t):
+			rank = 1
+		}
+		dist := rl.Vector2Distance(bb.Position, t.Position)
+		if rank > bestRank || rank == bestRank && dist < bestDist {
+			best, bestRank, bestDist = i, rank, dist
+		}
+	}
+	return best
+}
+
+// Perceive updates bb's perception fields from the target and this frame's stimuli.
+func (s Senses) Perceive(bb *Blackboard, target Target, stimuli []Stimulus, dt float32) {
+	wasVisible := bb.TargetVisible
+	bb.TargetVisible = s.canSee(bb, target)
+
+	if bb.TargetVisible {
+		bb.TargetPos = target.Position
+		bb.LastKnownPos = target.Position
+		bb.HasLastKnown = true
+		bb.TimeSinceSeen = 0
+		if !wasVisible && bb.Horde != nil {
+			bb.Horde.Alert(bb, target.Position)
+		}
+	} else {
+		bb.TimeSinceSeen += dt
+	}
+
+	// Noises point us at a position even when we can't see anything.
+	for _, st := range stimuli {
+		if rl.Vector2Distance(bb.Position, st.Position) <= st.Radius*s.Hearing {
+			if !bb.TargetVisible {
+				bb.LastKnownPos = st.Position
+				bb.HasLastKnown = true
+				bb.TimeSinceSeen = 0
+			}
+		}
+	}
+
+	// Lose track after a while
+	if !bb.TargetVisible && bb.HasLastKnown && bb.TimeSinceSeen > s.Memory {
+		bb.HasLastKnown = false
+	}
+}
+
+// canSee checks the sight cone in the direction the agent is facing.
+func (s Senses) canSee(bb *Blackboard, target Target) bool {
+	if target.Visibility <= 0 {
+		return false
+	}
+	dx := target.Position.X - bb.Position.X
+	dy := target.Position.Y - bb.Position.Y
+	dist := float32(math.Hypot(float64(dx), float64(dy)))
+
+	if dist <= s.TouchRange {
+		return true
+	}
+	if dist > s.SightRange*target.Visibility {
+		return false
+	}
+
+	// A cone of 90° or more either side sees all around
+	if s.SightHalfAngle >= 90 {
+		return true
+	}
+
+	// Behind us?
+	if (bb.FacingRight && dx < 0) || (!bb.FacingRight && dx > 0) {
+		return false
+	}
+	angle := math.Atan2(math.Abs(float64(dy)), math.Abs(float64(dx))) * 180 / math.Pi
+	return float32(angle) <= s.SightHalfAngle
+}
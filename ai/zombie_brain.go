@@ -0,0 +1,180 @@
+package ai
+
+import (
+	"math/rand"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Config holds the tuning knobs for the zombie behaviour tree.
+type Config struct {
+	AttackRange    float32 // distance at which an attack can start
+	HoldDistance   float32 // how close to hang back when no attack slot is free
+	WindUp         float32 // seconds an attack is telegraphed before it lands
+	AttackCooldown float32 // seconds between attacks
+	FleeHealth     float32 // run away below this fraction of MaxHealth (0 = never)
+	WanderSwitch   float32 // average seconds between wander direction changes
+}
+
+// DefaultZombieConfig matches the old hard-coded walker (attack at 50px).
+func DefaultZombieConfig() Config {
+	return Config{
+		AttackRange:    50,
+		HoldDistance:   110,
+		WindUp:         0.6,
+		AttackCooldown: 1.0,
+		FleeHealth:     0.25,
+		WanderSwitch:   3,
+	}
+}
+
+// ZombieBrain is the default behaviour tree:
+//
+//	flee (low health) > attack (in range) > chase (visible) > investigate (heard/remembered) > wander
+type ZombieBrain struct {
+	Tree
+	Cfg Config
+	rnd *rand.Rand
+}
+
+func NewZombieBrain(cfg Config, rnd *rand.Rand) *ZombieBrain {
+	zb := &ZombieBrain{Cfg: cfg, rnd: rnd}
+	zb.Root = Selector{
+		Sequence{Condition(zb.shouldFlee), Task(zb.flee)},
+		Sequence{Condition(zb.inAttackRange), Task(zb.attack)},
+		Sequence{Condition(targetVisible), Task(zb.chase)},
+		Sequence{Condition(hasLastKnown), Task(zb.investigate)},
+		Task(zb.wander),
+	}
+	return zb
+}
+
+func (zb *ZombieBrain) Think(bb *Blackboard, dt float32) Decision {
+	if bb.Cooldown > 0 {
+		bb.Cooldown -= dt
+	}
+	return zb.Tree.Think(bb, dt)
+}
+
+// ─── Conditions ───
+
+func targetVisible(bb *Blackboard) bool { return bb.TargetVisible }
+func hasLastKnown(bb *Blackboard) bool  { return bb.HasLastKnown }
+
+func (zb *ZombieBrain) shouldFlee(bb *Blackboard) bool {
+	return zb.Cfg.FleeHealth > 0 &&
+		bb.HealthFraction() <= zb.Cfg.FleeHealth &&
+		(bb.TargetVisible || bb.HasLastKnown)
+}
+
+func (zb *ZombieBrain) inAttackRange(bb *Blackboard) bool {
+	return bb.TargetVisible && bb.DistanceToTarget() <= zb.Cfg.AttackRange
+}
+
+// ─── Tasks ───
+
+func (zb *ZombieBrain) flee(bb *Blackboard, dt float32, out *Decision) Status {
+	zb.stopAttacking(bb)
+	bb.Fleeing = true
+	from := bb.LastKnownPos
+	if bb.TargetVisible {
+		from = bb.TargetPos
+	}
+	out.Action = ActFlee
+	out.MoveDir = -bb.dirTo(from.X)
+	return Running
+}
+
+func (zb *ZombieBrain) attack(bb *Blackboard, dt float32, out *Decision) Status {
+	bb.Fleeing = false
+	bb.FacingRight = bb.TargetPos.X >= bb.Position.X
+
+	// Someone else has the slot: wait our turn
+	if bb.Horde != nil && !bb.Horde.claimAttack(bb) {
+		out.Action = ActIdle
+		return Running
+	}
+	if bb.Cooldown > 0 {
+		out.Action = ActAttack
+		return Running
+	}
+
+	bb.WindUpTimer += dt
+	out.Action = ActWindUp
+	if bb.WindUpTimer >= zb.Cfg.WindUp {
+		bb.WindUpTimer = 0
+		bb.Cooldown = zb.Cfg.AttackCooldown
+		out.Action = ActAttack
+		out.Strike = true
+	}
+	return Running
+}
+
+func (zb *ZombieBrain) chase(bb *Blackboard, dt float32, out *Decision) Status {
+	zb.stopAttacking(bb)
+	bb.Fleeing = false
+
+	// Don't crowd the attackers if all slots are taken
+	if bb.Horde != nil && !bb.Horde.canAttack(bb) &&
+		rl.Vector2Distance(bb.Position, bb.TargetPos) <= zb.Cfg.HoldDistance {
+		bb.FacingRight = bb.TargetPos.X >= bb.Position.X
+		out.Action = ActIdle
+		return Running
+	}
+
+	out.Action = ActChase
+	out.MoveDir = bb.dirTo(bb.TargetPos.X)
+	out.HasGoal = true
+	out.Goal = bb.TargetPos
+	return Running
+}
+
+func (zb *ZombieBrain) investigate(bb *Blackboard, dt float32, out *Decision) Status {
+	zb.stopAttacking(bb)
+	bb.Fleeing = false
+
+	// Reached the spot and found nothing: give up
+	if abs32(bb.LastKnownPos.X-bb.Position.X) < 10 && abs32(bb.LastKnownPos.Y-bb.Position.Y) < 60 {
+		bb.HasLastKnown = false
+		out.Action = ActIdle
+		return Success
+	}
+	out.Action = ActInvestigate
+	out.MoveDir = bb.dirTo(bb.LastKnownPos.X)
+	out.HasGoal = true
+	out.Goal = bb.LastKnownPos
+	return Running
+}
+
+func (zb *ZombieBrain) wander(bb *Blackboard, dt float32, out *Decision) Status {
+	zb.stopAttacking(bb)
+	bb.Fleeing = false
+
+	bb.WanderTimer -= dt
+	if bb.WanderTimer <= 0 {
+		bb.WanderDir = float32(zb.rnd.Intn(3) - 1) // -1, 0 or +1
+		bb.WanderTimer = zb.Cfg.WanderSwitch * (0.5 + zb.rnd.Float32())
+	}
+	if bb.WanderDir == 0 {
+		out.Action = ActIdle
+		return Running
+	}
+	out.Action = ActWander
+	out.MoveDir = bb.WanderDir
+	return Running
+}
+
+// stopAttacking cancels a half-finished wind-up and gives up the attack slot.
+func (zb *ZombieBrain) stopAttacking(bb *Blackboard) {
+	bb.WindUpTimer = 0
+	if bb.Horde != nil {
+		bb.Horde.releaseAttack(bb)
+	}
+}
+
+func abs32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,324 @@
+package ai
+
+import (
+	"math/rand"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const dt = 0.125 // a tick that adds up exactly: the 0.6s wind-up lands on the 5th
+
+// agent is a zombie without a body: senses and a brain around a blackboard
+// standing still at the origin, facing right.
+type agent struct {
+	bb     Blackboard
+	senses Senses
+	brain  *ZombieBrain
+}
+
+func newAgent() *agent {
+	return &agent{
+		bb:     Blackboard{FacingRight: true, Health: 100, MaxHealth: 100},
+		senses: DefaultSenses(),
+		brain:  NewZombieBrain(DefaultZombieConfig(), rand.New(rand.NewSource(1))),
+	}
+}
+
+func (a *agent) tick(target Target, stimuli ...Stimulus) Decision {
+	a.senses.Perceive(&a.bb, target, stimuli, dt)
+	return a.brain.Think(&a.bb, dt)
+}
+
+func seen(x float32) Target   { return Target{Position: rl.NewVector2(x, 0), Visibility: 1} }
+func hidden(x float32) Target { return Target{Position: rl.NewVector2(x, 0)} }
+
+func gunshot(x float32) Stimulus {
+	return Stimulus{Kind: StimGunshot, Position: rl.NewVector2(x, 0), Radius: 600}
+}
+
+// step is a stretch of a scripted scenario: the same target and noises for
+// a number of ticks, and what the brain should be doing by the last of them.
+type step struct {
+	what    string
+	target  Target
+	stimuli []Stimulus
+	health  float32 // 0 leaves it as it was
+	ticks   int
+	want    Action
+	strike  bool    // the last tick lands a blow
+	moveDir float32 // checked unless 0
+}
+
+func TestZombieScenarios(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "wander, chase, wind up and strike",
+			steps: []step{
+				{what: "nobody about", target: hidden(1000), ticks: 40, want: -1},
+				{what: "player walks into view", target: seen(250), ticks: 1, want: ActChase, moveDir: 1},
+				{what: "player in reach", target: seen(40), ticks: 4, want: ActWindUp},
+				{what: "wind-up done", target: seen(40), ticks: 1, want: ActAttack, strike: true},
+				{what: "cooling down", target: seen(40), ticks: 1, want: ActAttack},
+				{what: "still cooling down", target: seen(40), ticks: 6, want: ActAttack},
+				{what: "second wind-up", target: seen(40), ticks: 5, want: ActAttack, strike: true},
+				{what: "player steps back", target: seen(200), ticks: 1, want: ActChase, moveDir: 1},
+			},
+		},
+		{
+			name: "stepping out of reach cancels the wind-up",
+			steps: []step{
+				{what: "player in reach", target: seen(40), ticks: 4, want: ActWindUp},
+				{what: "player steps back", target: seen(200), ticks: 1, want: ActChase},
+				{what: "back in reach", target: seen(40), ticks: 4, want: ActWindUp},
+				{what: "lands only now", target: seen(40), ticks: 1, want: ActAttack, strike: true},
+			},
+		},
+		{
+			name: "flee at low health",
+			steps: []step{
+				{what: "healthy", target: seen(100), ticks: 1, want: ActChase, moveDir: 1},
+				{what: "badly hurt", target: seen(100), health: 20, ticks: 1, want: ActFlee, moveDir: -1},
+				{what: "even in reach", target: seen(40), ticks: 3, want: ActFlee, moveDir: -1},
+				{what: "player behind", target: seen(-40), ticks: 1, want: ActFlee, moveDir: 1},
+				{what: "nothing to flee from", target: hidden(5000), ticks: 50, want: -1},
+			},
+		},
+		{
+			name: "memory runs out",
+			steps: []step{
+				{what: "spotted", target: seen(200), ticks: 1, want: ActChase},
+				{what: "player hides", target: hidden(200), ticks: 32, want: ActInvestigate, moveDir: 1},
+				{what: "forgotten after 4s", target: hidden(200), ticks: 1, want: -1},
+			},
+		},
+		{
+			name: "hearing",
+			steps: []step{
+				{what: "gunshot behind", target: hidden(-400), stimuli: []Stimulus{gunshot(-400)}, ticks: 1, want: ActInvestigate, moveDir: -1},
+				{what: "goes looking", target: hidden(-400), ticks: 20, want: ActInvestigate, moveDir: -1},
+				{what: "another shot renews it", target: hidden(-400), stimuli: []Stimulus{gunshot(-300)}, ticks: 1, want: ActInvestigate},
+				{what: "remembered 4s", target: hidden(-400), ticks: 32, want: ActInvestigate},
+				{what: "then forgotten", target: hidden(-400), ticks: 1, want: -1},
+				{what: "too far to hear", target: hidden(2000), stimuli: []Stimulus{gunshot(2000)}, ticks: 1, want: -1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newAgent()
+			for _, s := range tt.steps {
+				if s.health > 0 {
+					a.bb.Health = s.health
+				}
+				var d Decision
+				for i := 0; i < s.ticks; i++ {
+					d = a.tick(s.target, s.stimuli...)
+					if i < s.ticks-1 && d.Strike {
+						t.Fatalf("%s: struck early, on tick %d of %d", s.what, i+1, s.ticks)
+					}
+				}
+				if s.want == -1 { // idle or wandering, whichever the dice say
+					if d.Action != ActIdle && d.Action != ActWander {
+						t.Fatalf("%s: %v, want idle or wander", s.what, d.Action)
+					}
+				} else if d.Action != s.want {
+					t.Fatalf("%s: action %v, want %v", s.what, d.Action, s.want)
+				}
+				if d.Strike != s.strike {
+					t.Fatalf("%s: strike %v, want %v", s.what, d.Strike, s.strike)
+				}
+				if s.moveDir != 0 && d.MoveDir != s.moveDir {
+					t.Fatalf("%s: moving %v, want %v", s.what, d.MoveDir, s.moveDir)
+				}
+			}
+		})
+	}
+}
+
+func TestWanderIsSeeded(t *testing.T) {
+	run := func() []float32 {
+		a := newAgent()
+		var dirs []float32
+		for range 200 {
+			dirs = append(dirs, a.tick(hidden(5000)).MoveDir)
+		}
+		return dirs
+	}
+	a, b := run(), run()
+	moved := false
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("tick %d: the same seed wandered %v, then %v", i, a[i], b[i])
+		}
+		moved = moved || a[i] != 0
+	}
+	if !moved {
+		t.Error("never wandered anywhere in 25s")
+	}
+}
+
+func TestSight(t *testing.T) {
+	tests := []struct {
+		name   string
+		facing bool
+		target Target
+		want   bool
+	}{
+		{"straight ahead", true, seen(250), true},
+		{"out of range", true, seen(350), false},
+		{"behind", false, seen(250), false},
+		{"behind but touching", false, seen(50), true},
+		{"invisible", true, hidden(100), false},
+		{"sitting shrinks the range", true, Target{Position: rl.NewVector2(150, 0), Visibility: 0.4}, false},
+		{"sitting close by", true, Target{Position: rl.NewVector2(100, 0), Visibility: 0.4}, true},
+		{"inside the cone", true, Target{Position: rl.NewVector2(200, -100), Visibility: 1}, true}, // ~27°
+		{"above the cone", true, Target{Position: rl.NewVector2(100, -150), Visibility: 1}, false}, // ~56°
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bb := Blackboard{FacingRight: tt.facing}
+			DefaultSenses().Perceive(&bb, tt.target, nil, dt)
+			if bb.TargetVisible != tt.want {
+				t.Errorf("visible %v, want %v", bb.TargetVisible, tt.want)
+			}
+		})
+	}
+}
+
+func TestChooseTarget(t *testing.T) {
+	provoked := func(tg Target) Target { tg.Provoked = true; return tg }
+	tests := []struct {
+		name    string
+		targets []Target
+		want    int
+	}{
+		{"none", nil, -1},
+		{"only one", []Target{seen(1000)}, 0},
+		{"nearer in sight", []Target{seen(250), seen(100)}, 1},
+		{"in sight over nearer unseen", []Target{hidden(50), seen(200)}, 1},
+		{"behind is out of sight", []Target{seen(-100), seen(250)}, 1},
+		{"nearest when none in sight", []Target{hidden(900), hidden(-400)}, 1},
+		{"provoked over nearer", []Target{seen(40), provoked(seen(280))}, 1},
+		{"provoked even out of sight", []Target{seen(40), provoked(hidden(-500))}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bb := Blackboard{FacingRight: true}
+			if got := DefaultSenses().ChooseTarget(&bb, tt.targets); got != tt.want {
+				t.Errorf("chose %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHordeAttackSlots(t *testing.T) {
+	h := NewHorde(2, 400)
+	agents := make([]*agent, 4)
+	for i := range agents {
+		agents[i] = newAgent()
+		agents[i].bb.ID = i
+		h.Join(&agents[i].bb)
+	}
+
+	winding := func(target Target) []int {
+		var ids []int
+		for i, a := range agents {
+			if d := a.tick(target); d.Action == ActWindUp || d.Action == ActAttack {
+				ids = append(ids, i)
+			}
+		}
+		return ids
+	}
+
+	// Everyone is in reach; only two get to swing, the same two every tick
+	first := winding(seen(40))
+	if len(first) != 2 {
+		t.Fatalf("%d attacking, want 2 (%v)", len(first), first)
+	}
+	for range 10 {
+		if got := winding(seen(40)); len(got) != 2 || got[0] != first[0] || got[1] != first[1] {
+			t.Fatalf("attackers changed from %v to %v", first, got)
+		}
+	}
+
+	// One attacker loses the player and a waiting one takes over its slot;
+	// then another leaves the horde, freeing its slot as well
+	quitter, leaver := first[0], first[1]
+	attacking := func(skip int) map[int]bool {
+		got := map[int]bool{}
+		for range 2 { // a zombie ticked before the slot frees up gets it next tick
+			got = map[int]bool{}
+			for i, a := range agents {
+				target := seen(40)
+				if i == quitter {
+					target = hidden(40)
+				}
+				if i == skip {
+					continue
+				}
+				if d := a.tick(target); d.Action == ActWindUp || d.Action == ActAttack {
+					got[i] = true
+				}
+			}
+		}
+		return got
+	}
+	got := attacking(-1)
+	if len(got) != 2 || got[quitter] || !got[leaver] {
+		t.Fatalf("attacking %v after %d lost the player, want %d and one that was waiting", got, quitter, leaver)
+	}
+
+	h.Leave(&agents[leaver].bb)
+	got = attacking(leaver)
+	if len(got) != 2 || got[quitter] || got[leaver] {
+		t.Errorf("attacking %v after %d left, want the two that were waiting", got, leaver)
+	}
+}
+
+func TestHordeHoldsBack(t *testing.T) {
+	h := NewHorde(1, 400)
+	biter, waiter := newAgent(), newAgent()
+	h.Join(&biter.bb)
+	h.Join(&waiter.bb)
+	waiter.bb.Position = rl.NewVector2(-60, 0)
+
+	if d := biter.tick(seen(40)); d.Action != ActWindUp {
+		t.Fatalf("first zombie %v, want wind-up", d.Action)
+	}
+	if d := waiter.tick(seen(40)); d.Action != ActIdle || d.MoveDir != 0 {
+		t.Errorf("second zombie %v moving %v, want holding back", d.Action, d.MoveDir)
+	}
+}
+
+func TestHordeAlert(t *testing.T) {
+	h := NewHorde(2, 400)
+	spotter, near, far := newAgent(), newAgent(), newAgent()
+	for _, a := range []*agent{spotter, near, far} {
+		h.Join(&a.bb)
+	}
+	near.bb.Position = rl.NewVector2(-300, 0)
+	near.bb.FacingRight = false
+	far.bb.Position = rl.NewVector2(-1000, 0)
+
+	spotter.tick(seen(200))
+	if !near.bb.HasLastKnown || near.bb.LastKnownPos != rl.NewVector2(200, 0) {
+		t.Errorf("nearby zombie wasn't told: %+v", near.bb)
+	}
+	if d := near.brain.Think(&near.bb, dt); d.Action != ActInvestigate || d.MoveDir != 1 {
+		t.Errorf("nearby zombie %v moving %v, want investigating to the right", d.Action, d.MoveDir)
+	}
+	if far.bb.HasLastKnown {
+		t.Error("zombie out of earshot was told")
+	}
+
+	// Only the first sighting is shouted about
+	near.bb.HasLastKnown = false
+	spotter.tick(seen(210))
+	if near.bb.HasLastKnown {
+		t.Error("a zombie that kept the player in sight alerted again")
+	}
+}
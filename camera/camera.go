@@ -0,0 +1,151 @@
+// Package camera decides what part of the world is on screen. It follows a
+// target (the player) through a dead zone, eases towards where it wants to
+// be, looks ahead in the direction the target faces, stays inside the
+// level's bounds, and shakes with trauma from explosions and hits.
+package camera
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Config holds the camera's tuning knobs.
+type Config struct {
+	DeadZone       rl.Vector2 // half-size of the box round the centre the target moves in freely
+	Smoothing      float32    // how fast the camera closes in on where it wants to be, per second; 0 = at once
+	LookAhead      float32    // how far ahead of the target to look, in its facing direction
+	LookAheadSpeed float32    // how fast the look-ahead swings round when the target turns, per second
+	Zoom           float32
+
+	MaxShake    float32 // offset in pixels at full trauma
+	MaxRoll     float32 // rotation in degrees at full trauma
+	ShakeSpeed  float32 // how fast the shake wobbles
+	TraumaDecay float32 // trauma lost per second
+}
+
+// DefaultConfig matches the old camera's 200px edge margin at 800 wide.
+func DefaultConfig() Config {
+	return Config{
+		DeadZone:       rl.NewVector2(200, 80),
+		Smoothing:      8,
+		LookAhead:      80,
+		LookAheadSpeed: 2,
+		Zoom:           1,
+
+		MaxShake:    14,
+		MaxRoll:     2,
+		ShakeSpeed:  30,
+		TraumaDecay: 1.5,
+	}
+}
+
+// Camera follows a target around a level.
+type Camera struct {
+	Cfg    Config
+	Bounds rl.Rectangle // the camera never shows anything outside this
+
+	screen    rl.Vector2 // size of the screen it draws to
+	focus     rl.Vector2 // centre of the dead zone
+	pos       rl.Vector2 // where the camera looks, before shaking
+	lookAhead float32
+	trauma    float32 // 0-1; the shake grows with its square
+	time      float32 // drives the shake
+	shake     rl.Vector2
+	roll      float32
+}
+
+// New makes a camera drawing to a screenW×screenH screen.
+func New(cfg Config, screenW, screenH float32, bounds rl.Rectangle) *Camera {
+	return &Camera{Cfg: cfg, Bounds: bounds, screen: rl.NewVector2(screenW, screenH)}
+}
+
+// Snap puts the camera straight on target, e.g. after a scene change, and
+// stops any shake.
+func (c *Camera) Snap(target rl.Vector2) {
+	c.focus = target
+	c.lookAhead = 0
+	c.pos = c.clamp(target)
+	c.trauma, c.shake, c.roll = 0, rl.Vector2{}, 0
+}
+
+// AddTrauma shakes the screen; amount is 0-1 and adds up to at most 1.
+func (c *Camera) AddTrauma(amount float32) {
+	c.trauma = min(c.trauma+amount, 1)
+}
+
+// Update moves the camera on by dt seconds towards a target facing right or
+// left.
+func (c *Camera) Update(target rl.Vector2, facingRight bool, dt float32) {
+	// The dead zone only drags along once the target reaches its edge
+	dz := c.Cfg.DeadZone
+	c.focus.X = max(min(c.focus.X, target.X+dz.X), target.X-dz.X)
+	c.focus.Y = max(min(c.focus.Y, target.Y+dz.Y), target.Y-dz.Y)
+
+	want := c.Cfg.LookAhead
+	if !facingRight {
+		want = -want
+	}
+	c.lookAhead += (want - c.lookAhead) * ease(c.Cfg.LookAheadSpeed, dt)
+
+	goal := c.clamp(rl.NewVector2(c.focus.X+c.lookAhead, c.focus.Y))
+	c.pos = c.clamp(rl.Vector2Lerp(c.pos, goal, ease(c.Cfg.Smoothing, dt)))
+
+	c.trauma = max(c.trauma-c.Cfg.TraumaDecay*dt, 0)
+	c.time += dt
+	s := c.trauma * c.trauma
+	t := c.time * c.Cfg.ShakeSpeed
+	c.shake = rl.NewVector2(c.Cfg.MaxShake*s*wobble(t, 0), c.Cfg.MaxShake*s*wobble(t, 1))
+	c.roll = c.Cfg.MaxRoll * s * wobble(t, 2)
+}
+
+// Camera2D is the raylib camera for this frame, shake included.
+func (c *Camera) Camera2D() rl.Camera2D {
+	return rl.Camera2D{
+		Target:   rl.Vector2Add(c.pos, c.shake),
+		Offset:   rl.NewVector2(c.screen.X/2, c.screen.Y/2),
+		Rotation: c.roll,
+		Zoom:     c.Cfg.Zoom,
+	}
+}
+
+// View is the part of the world on screen, not counting the shake.
+func (c *Camera) View() rl.Rectangle {
+	half := c.halfView()
+	return rl.NewRectangle(c.pos.X-half.X, c.pos.Y-half.Y, half.X*2, half.Y*2)
+}
+
+func (c *Camera) halfView() rl.Vector2 {
+	return rl.NewVector2(c.screen.X/2/c.Cfg.Zoom, c.screen.Y/2/c.Cfg.Zoom)
+}
+
+// clamp keeps a camera centre far enough inside Bounds that nothing beyond
+// them shows; a level smaller than the view is centred.
+func (c *Camera) clamp(p rl.Vector2) rl.Vector2 {
+	half := c.halfView()
+	b := c.Bounds
+	return rl.NewVector2(clampAxis(p.X, b.X+half.X, b.X+b.Width-half.X), clampAxis(p.Y, b.Y+half.Y, b.Y+b.Height-half.Y))
+}
+
+func clampAxis(v, lo, hi float32) float32 {
+	if lo > hi {
+		return (lo + hi) / 2
+	}
+	return max(min(v, hi), lo)
+}
+
+// ease is how much of the remaining distance to cover in dt when closing in
+// at rate per second; the same whatever the frame rate.
+func ease(rate, dt float32) float32 {
+	if rate <= 0 {
+		return 1
+	}
+	return 1 - float32(math.Exp(float64(-rate*dt)))
+}
+
+// wobble is smooth noise in -1..1; each channel wobbles differently.
+func wobble(t float32, channel int) float32 {
+	p := float64(channel) * 1.7
+	x := float64(t)
+	return float32(math.Sin(x+p)*0.6 + math.Sin(x*2.3+p*2)*0.4)
+}
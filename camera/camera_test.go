@@ -0,0 +1,214 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	screenW, screenH = 800, 450
+	tick             = float32(1) / 60
+)
+
+var level = rl.NewRectangle(0, -1000, 5000, 1450)
+
+// still is a camera that follows without easing, looking ahead or shaking,
+// so each test can turn on just what it checks.
+func still() Config {
+	cfg := DefaultConfig()
+	cfg.Smoothing = 0
+	cfg.LookAhead = 0
+	return cfg
+}
+
+func centre(r rl.Rectangle) rl.Vector2 {
+	return rl.NewVector2(r.X+r.Width/2, r.Y+r.Height/2)
+}
+
+func near(a, b float32) bool {
+	return math.Abs(float64(a-b)) < 0.01
+}
+
+// inside reports whether the view r shows nothing outside bounds.
+func inside(r, bounds rl.Rectangle) bool {
+	const slack = 0.001
+	return r.X >= bounds.X-slack && r.Y >= bounds.Y-slack &&
+		r.X+r.Width <= bounds.X+bounds.Width+slack && r.Y+r.Height <= bounds.Y+bounds.Height+slack
+}
+
+func TestDeadZone(t *testing.T) {
+	start := rl.NewVector2(2000, 0)
+	tests := []struct {
+		name   string
+		target rl.Vector2
+		want   rl.Vector2 // centre of the view afterwards
+	}{
+		{"inside the zone", rl.NewVector2(2150, 50), start},
+		{"on the edge", rl.NewVector2(2200, -80), start},
+		{"past the right edge", rl.NewVector2(2300, 0), rl.NewVector2(2100, 0)},
+		{"past the left edge", rl.NewVector2(1700, 0), rl.NewVector2(1900, 0)},
+		{"below", rl.NewVector2(2000, 130), rl.NewVector2(2000, 50)},
+		{"above", rl.NewVector2(2000, -200), rl.NewVector2(2000, -120)},
+		{"past a corner", rl.NewVector2(2250, 100), rl.NewVector2(2050, 20)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(still(), screenW, screenH, level)
+			c.Snap(start)
+			c.Update(tt.target, true, tick)
+			if got := centre(c.View()); !near(got.X, tt.want.X) || !near(got.Y, tt.want.Y) {
+				t.Errorf("view centre %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSmoothing(t *testing.T) {
+	cfg := still()
+	cfg.Smoothing = 8
+	target := rl.NewVector2(2600, 0) // 400 past the dead zone
+
+	c := New(cfg, screenW, screenH, level)
+	c.Snap(rl.NewVector2(2000, 0))
+	c.Update(target, true, tick)
+	if x := centre(c.View()).X; x <= 2000 || x >= 2400 {
+		t.Fatalf("after one tick the view is at %v, want part way from 2000 to 2400", x)
+	}
+
+	// Half a second at 60 and at 30 frames a second ends up in the same place
+	at := func(dt float32, n int) float32 {
+		c := New(cfg, screenW, screenH, level)
+		c.Snap(rl.NewVector2(2000, 0))
+		for range n {
+			c.Update(target, true, dt)
+		}
+		return centre(c.View()).X
+	}
+	if a, b := at(tick, 30), at(2*tick, 15); !near(a, b) {
+		t.Errorf("smoothing depends on frame rate: %v at 60fps, %v at 30fps", a, b)
+	}
+	if x := at(tick, 300); !near(x, 2400) {
+		t.Errorf("after 5s the view is at %v, want settled on 2400", x)
+	}
+}
+
+func TestLookAhead(t *testing.T) {
+	cfg := still()
+	cfg.LookAhead = 80
+	c := New(cfg, screenW, screenH, level)
+	target := rl.NewVector2(2000, 0)
+	c.Snap(target)
+
+	for range 600 {
+		c.Update(target, true, tick)
+	}
+	if x := centre(c.View()).X; !near(x, 2080) {
+		t.Errorf("facing right the view is at %v, want 2080", x)
+	}
+	c.Update(target, false, tick)
+	if x := centre(c.View()).X; x >= 2080 || x <= 1920 {
+		t.Errorf("turning round jumped the view to %v, want it to swing", x)
+	}
+	for range 600 {
+		c.Update(target, false, tick)
+	}
+	if x := centre(c.View()).X; !near(x, 1920) {
+		t.Errorf("facing left the view is at %v, want 1920", x)
+	}
+}
+
+func TestViewStaysInBounds(t *testing.T) {
+	cfg := DefaultConfig()
+	path := []rl.Vector2{
+		{X: -500, Y: 0}, {X: 0, Y: 400}, {X: 2500, Y: -2000}, {X: 6000, Y: 300}, {X: 5000, Y: 5000}, {X: 100, Y: 100},
+	}
+	for _, zoom := range []float32{0.5, 1, 2} {
+		cfg.Zoom = zoom
+		c := New(cfg, screenW, screenH, level)
+		c.Snap(path[0])
+		for i, p := range path {
+			for n := range 240 {
+				c.AddTrauma(0.1)
+				c.Update(p, n%50 < 25, tick*float32(1+n%3))
+				if v := c.View(); !inside(v, level) {
+					t.Fatalf("zoom %v, leg %d, tick %d: view %v outside %v", zoom, i, n, v, level)
+				}
+			}
+		}
+	}
+}
+
+func TestSmallLevelIsCentred(t *testing.T) {
+	tests := []struct {
+		name   string
+		bounds rl.Rectangle
+	}{
+		{"narrower than the view", rl.NewRectangle(100, -1000, 600, 1450)},
+		{"shorter than the view", rl.NewRectangle(0, 0, 5000, 300)},
+		{"smaller both ways", rl.NewRectangle(50, 50, 400, 200)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(DefaultConfig(), screenW, screenH, tt.bounds)
+			mid := centre(tt.bounds)
+			c.Snap(rl.NewVector2(0, 0))
+			for _, p := range []rl.Vector2{{X: -300, Y: -300}, {X: 5000, Y: 900}} {
+				for range 120 {
+					c.Update(p, true, tick)
+				}
+				got := centre(c.View())
+				if tt.bounds.Width < screenW && !near(got.X, mid.X) {
+					t.Errorf("view centre x %v, want the level's %v", got.X, mid.X)
+				}
+				if tt.bounds.Height < screenH && !near(got.Y, mid.Y) {
+					t.Errorf("view centre y %v, want the level's %v", got.Y, mid.Y)
+				}
+			}
+		})
+	}
+}
+
+func TestTrauma(t *testing.T) {
+	c := New(still(), screenW, screenH, level)
+	target := rl.NewVector2(2000, 0)
+	c.Snap(target)
+
+	c.AddTrauma(0.6)
+	c.AddTrauma(0.6)
+	if c.trauma != 1 {
+		t.Fatalf("trauma %v, want capped at 1", c.trauma)
+	}
+
+	shook := false
+	for range 30 {
+		c.Update(target, true, tick)
+		if cam := c.Camera2D(); cam.Target != target || cam.Rotation != 0 {
+			shook = true
+		}
+	}
+	if !shook {
+		t.Error("full trauma didn't shake the camera")
+	}
+	if got := centre(c.View()); got != target {
+		t.Errorf("shaking moved the view to %v, want it on %v", got, target)
+	}
+
+	// At 1.5 a second, full trauma is gone in 2/3 of a second
+	for range 20 {
+		c.Update(target, true, tick)
+	}
+	if c.trauma != 0 {
+		t.Fatalf("trauma %v after 50 ticks, want 0", c.trauma)
+	}
+	if cam := c.Camera2D(); cam.Target != target || cam.Rotation != 0 {
+		t.Errorf("camera still shaking without trauma: target %v, rotation %v", cam.Target, cam.Rotation)
+	}
+
+	c.AddTrauma(1)
+	c.Snap(target)
+	if c.trauma != 0 {
+		t.Error("Snap didn't stop the shake")
+	}
+}
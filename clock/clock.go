@@ -0,0 +1,32 @@
+// Package clock is the game's own time. It only moves when the world is
+// updated, by the length of each tick, so the pause menu stops it and a
+// replay sees exactly the times it was recorded with.
+package clock
+
+import "time"
+
+var (
+	now = time.Unix(0, 0) // game time starts at an arbitrary, non-zero instant
+	dt  float32
+)
+
+// Tick moves game time on by one tick lasting seconds.
+func Tick(seconds float32) {
+	dt = seconds
+	now = now.Add(time.Duration(float64(seconds) * float64(time.Second)))
+}
+
+// Now is the current game time.
+func Now() time.Time {
+	return now
+}
+
+// Since is the game time elapsed since t.
+func Since(t time.Time) time.Duration {
+	return now.Sub(t)
+}
+
+// Delta is the length of the current tick in seconds.
+func Delta() float32 {
+	return dt
+}
@@ -0,0 +1,182 @@
+// Package config gathers how the game is started: window, frame rate, where
+// the assets and the save live, logging, and the run to play. Every setting
+// has a default, which a JSON config file can change, then an environment
+// variable, then a command line flag.
+//
+// The config file is config.json in the working directory, if there is one,
+// or whatever -config (or PLATFORMER_CONFIG) names. Its keys are the flag
+// names with underscores, e.g. "target_fps"; the environment variables are
+// the same in capitals after PLATFORMER_, e.g. PLATFORMER_TARGET_FPS.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	defaultFile = "config.json"
+	envPrefix   = "PLATFORMER_"
+)
+
+// Config is everything the game can be started with.
+type Config struct {
+	Width      int     `json:"width"` // window size; 0 keeps the one picked under Settings
+	Height     int     `json:"height"`
+	Fullscreen bool    `json:"fullscreen"` // start fullscreen, whatever Settings says
+	VSync      bool    `json:"vsync"`
+	TargetFPS  int     `json:"target_fps"`  // 0 = no cap
+	AssetRoot  string  `json:"asset_root"`  // folder holding the sprites, sounds and data files
+	SavePath   string  `json:"save_path"`   // the game database; save slots go in saves/ beside it
+	LogLevel   string  `json:"log_level"`   // raylib's: debug, info, warning, error or none (which also silences the game's)
+	StartLevel string  `json:"start_level"` // outside or inside
+	Seed       int64   `json:"seed"`        // seed for new games; 0 picks one from the clock
+	Zoom       float64 `json:"zoom"`        // camera zoom; 2 shows the world twice as big
+
+	Record   string `json:"record"`   // write a replay of the run to this file
+	Replay   string `json:"replay"`   // play back this replay file instead of reading the controls
+	Headless bool   `json:"headless"` // with Replay: no window or sound, quit when the replay ends
+}
+
+// Default is the game as it starts with no config at all.
+func Default() Config {
+	return Config{
+		AssetRoot:  "assets",
+		SavePath:   "game_data.db",
+		LogLevel:   "info",
+		StartLevel: "outside",
+		Zoom:       1,
+	}
+}
+
+// AssetRoot is the folder Asset looks in; the game sets it from its Config.
+var AssetRoot = Default().AssetRoot
+
+// Asset returns the path of a file under the asset root, e.g.
+// Asset("sounds/reload.mp3").
+func Asset(name string) string {
+	return filepath.Join(AssetRoot, name)
+}
+
+var logLevels = map[string]rl.TraceLogLevel{
+	"debug":   rl.LogDebug,
+	"info":    rl.LogInfo,
+	"warning": rl.LogWarning,
+	"error":   rl.LogError,
+	"none":    rl.LogNone,
+}
+
+// TraceLevel is how much raylib should log. The level only applies to
+// raylib: the game's own log has no levels, and is either on or, for "none",
+// off.
+func (c Config) TraceLevel() rl.TraceLogLevel {
+	return logLevels[c.LogLevel]
+}
+
+// flagSet binds a flag to every field of c.
+func (c *Config) flagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("platformer-game", flag.ContinueOnError)
+	fs.IntVar(&c.Width, "width", c.Width, "window width (0 keeps the one picked under Settings)")
+	fs.IntVar(&c.Height, "height", c.Height, "window height")
+	fs.BoolVar(&c.Fullscreen, "fullscreen", c.Fullscreen, "start fullscreen")
+	fs.BoolVar(&c.VSync, "vsync", c.VSync, "wait for the monitor's vertical sync")
+	fs.IntVar(&c.TargetFPS, "target-fps", c.TargetFPS, "frame rate cap (0 = no cap)")
+	fs.StringVar(&c.AssetRoot, "asset-root", c.AssetRoot, "folder holding the game's assets")
+	fs.StringVar(&c.SavePath, "save-path", c.SavePath, "game database; save slots go in saves/ beside it")
+	fs.StringVar(&c.LogLevel, "log-level", c.LogLevel, "how much raylib logs: debug, info, warning, error or none (none silences the game's log too)")
+	fs.StringVar(&c.StartLevel, "start-level", c.StartLevel, "level a game starts in: outside or inside")
+	fs.Int64Var(&c.Seed, "seed", c.Seed, "seed for new games (0 picks one at random)")
+	fs.Float64Var(&c.Zoom, "zoom", c.Zoom, "camera zoom (2 shows the world twice as big)")
+	fs.StringVar(&c.Record, "record", c.Record, "record the run's input to this replay file")
+	fs.StringVar(&c.Replay, "replay", c.Replay, "play back a replay file")
+	fs.BoolVar(&c.Headless, "headless", c.Headless, "with -replay: don't draw or play sound, and quit when the replay ends")
+	return fs
+}
+
+// envName is the environment variable for a flag, e.g. PLATFORMER_TARGET_FPS.
+func envName(flagName string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// Load builds the config from the defaults, the config file, the environment
+// and the command line args, each overriding the one before. It returns
+// flag.ErrHelp after printing the usage for -h.
+func Load(args []string) (Config, error) {
+	// The flags are parsed first, to find the config file, but applied last
+	cmd := Default()
+	fs := cmd.flagSet()
+	file := fs.String("config", "", "JSON config file (default "+defaultFile+", if there is one)")
+	if err := fs.Parse(args); err != nil {
+		return cmd, err
+	}
+
+	cfg := Default()
+	path, required := *file, true
+	if path == "" {
+		path = os.Getenv(envName("config"))
+	}
+	if path == "" {
+		path, required = defaultFile, false
+	}
+	if err := cfg.readFile(path, required); err != nil {
+		return cfg, err
+	}
+
+	out := cfg.flagSet()
+	var err error
+	out.VisitAll(func(f *flag.Flag) {
+		if v, ok := os.LookupEnv(envName(f.Name)); ok && err == nil {
+			if e := out.Set(f.Name, v); e != nil {
+				err = fmt.Errorf("%s: %w", envName(f.Name), e)
+			}
+		}
+	})
+	if err != nil {
+		return cfg, err
+	}
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name != "config" {
+			out.Set(f.Name, f.Value.String())
+		}
+	})
+	return cfg, cfg.validate()
+}
+
+// readFile fills c from a JSON file. A missing file is only an error if it
+// was asked for.
+func (c *Config) readFile(path string, required bool) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !required {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func (c Config) validate() error {
+	if c.Width < 0 || c.Height < 0 || (c.Width == 0) != (c.Height == 0) {
+		return fmt.Errorf("window size %dx%d: give both a width and a height, or neither", c.Width, c.Height)
+	}
+	if c.TargetFPS < 0 {
+		return fmt.Errorf("target FPS %d is negative", c.TargetFPS)
+	}
+	if c.Zoom <= 0 {
+		return fmt.Errorf("zoom %g must be above 0", c.Zoom)
+	}
+	if _, ok := logLevels[c.LogLevel]; !ok {
+		return fmt.Errorf("unknown log level %q", c.LogLevel)
+	}
+	return nil
+}
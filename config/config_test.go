@@ -0,0 +1,188 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// inTempDir runs the test in an empty folder, so a config.json lying around
+// doesn't leak in, and returns the folder.
+func inTempDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	return dir
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  string // config.json in the working folder; "" for none
+		env   map[string]string
+		args  []string
+		check func(t *testing.T, c Config)
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, c Config) {
+				if c != Default() {
+					t.Errorf("got %+v, want the defaults %+v", c, Default())
+				}
+			},
+		},
+		{
+			name: "file over defaults",
+			file: `{"width": 1024, "height": 576, "target_fps": 30, "log_level": "warning"}`,
+			check: func(t *testing.T, c Config) {
+				if c.Width != 1024 || c.Height != 576 || c.TargetFPS != 30 || c.LogLevel != "warning" {
+					t.Errorf("file settings not applied: %+v", c)
+				}
+				if c.AssetRoot != "assets" || c.Zoom != 1 {
+					t.Errorf("defaults the file doesn't mention changed: %+v", c)
+				}
+			},
+		},
+		{
+			name: "env over file",
+			file: `{"target_fps": 30, "seed": 5}`,
+			env:  map[string]string{"PLATFORMER_TARGET_FPS": "60", "PLATFORMER_ASSET_ROOT": "/opt/assets"},
+			check: func(t *testing.T, c Config) {
+				if c.TargetFPS != 60 || c.AssetRoot != "/opt/assets" {
+					t.Errorf("env not applied: %+v", c)
+				}
+				if c.Seed != 5 {
+					t.Errorf("seed %d, want 5 from the file", c.Seed)
+				}
+			},
+		},
+		{
+			name: "flags over env",
+			file: `{"seed": 5, "zoom": 2}`,
+			env:  map[string]string{"PLATFORMER_SEED": "7", "PLATFORMER_FULLSCREEN": "true"},
+			args: []string{"-seed", "9", "-fullscreen=false"},
+			check: func(t *testing.T, c Config) {
+				if c.Seed != 9 || c.Fullscreen {
+					t.Errorf("flags not applied: %+v", c)
+				}
+				if c.Zoom != 2 {
+					t.Errorf("zoom %g, want 2 from the file", c.Zoom)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := inTempDir(t)
+			if tt.file != "" {
+				writeFile(t, filepath.Join(dir, defaultFile), tt.file)
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			c, err := Load(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, c)
+		})
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	dir := inTempDir(t)
+	writeFile(t, filepath.Join(dir, defaultFile), `{"seed": 1}`)
+	writeFile(t, filepath.Join(dir, "env.json"), `{"seed": 2}`)
+	writeFile(t, filepath.Join(dir, "flag.json"), `{"seed": 3}`)
+
+	t.Setenv("PLATFORMER_CONFIG", "env.json")
+	c, err := Load(nil)
+	if err != nil || c.Seed != 2 {
+		t.Errorf("PLATFORMER_CONFIG: seed %d, err %v; want 2 from env.json", c.Seed, err)
+	}
+	c, err = Load([]string{"-config", "flag.json"})
+	if err != nil || c.Seed != 3 {
+		t.Errorf("-config: seed %d, err %v; want 3 from flag.json", c.Seed, err)
+	}
+	if _, err := Load([]string{"-config", "missing.json"}); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing -config file: err %v, want not exist", err)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		env  map[string]string
+		args []string
+	}{
+		{name: "broken file", file: `{"width": `},
+		{name: "wrong type in file", file: `{"width": "wide"}`},
+		{name: "bad env value", env: map[string]string{"PLATFORMER_WIDTH": "wide"}},
+		{name: "unknown flag", args: []string{"-colour", "red"}},
+		{name: "invalid result", args: []string{"-zoom", "0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := inTempDir(t)
+			if tt.file != "" {
+				writeFile(t, filepath.Join(dir, defaultFile), tt.file)
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if _, err := Load(tt.args); err == nil {
+				t.Error("Load succeeded")
+			}
+		})
+	}
+
+	inTempDir(t)
+	if _, err := Load([]string{"-h"}); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("-h: err %v, want flag.ErrHelp", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(c *Config)
+		ok     bool
+	}{
+		{"defaults", func(c *Config) {}, true},
+		{"window size", func(c *Config) { c.Width, c.Height = 1280, 720 }, true},
+		{"width only", func(c *Config) { c.Width = 1280 }, false},
+		{"height only", func(c *Config) { c.Height = 720 }, false},
+		{"negative size", func(c *Config) { c.Width, c.Height = -1, 720 }, false},
+		{"negative fps", func(c *Config) { c.TargetFPS = -1 }, false},
+		{"zero zoom", func(c *Config) { c.Zoom = 0 }, false},
+		{"negative zoom", func(c *Config) { c.Zoom = -2 }, false},
+		{"log level none", func(c *Config) { c.LogLevel = "none" }, true},
+		{"unknown log level", func(c *Config) { c.LogLevel = "verbose" }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Default()
+			tt.change(&c)
+			if err := c.validate(); (err == nil) != tt.ok {
+				t.Errorf("validate() = %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"platformer-game/config"
+	"platformer-game/gameobjects"
+	"platformer-game/ui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	arenaLeft      = 3600 // X of the left arena gate
+	arenaRight     = 4700 // X of the right arena gate
+	bossUnlockWave = 4    // the boss is waiting once the spawner reaches this wave
+	bossName       = "The Butcher"
+)
+
+// BossEncounter is the arena fight at the far end of the street. Walking into
+// the arena (once the boss is unlocked) slams both gates shut; they open again
+// when the boss dies.
+type BossEncounter struct {
+	Boss     *gameobjects.Zombie
+	Gates    []*gameobjects.Door // [0] = left, [1] = right
+	Active   bool
+	Defeated bool
+}
+
+var bossFight *BossEncounter
+
+func NewBossEncounter() *BossEncounter {
+	gateY := float32(worldHeight - 128)
+	b := &BossEncounter{
+		Gates: []*gameobjects.Door{
+			gameobjects.NewAnimatedDoor("ArenaGateLeft", arenaLeft, gateY, config.Asset(doorSheet), doorRects, 100),
+			gameobjects.NewAnimatedDoor("ArenaGateRight", arenaRight, gateY, config.Asset(doorSheet), doorRects, 100),
+		},
+	}
+	// Gates start wide open
+	for _, g := range b.Gates {
+		g.State = gameobjects.DoorOpen
+		g.CurrentFrame = len(g.Frames) - 1
+	}
+	return b
+}
+
+// Update starts the fight when the player walks into the arena, keeps them
+// locked in while the boss lives and hands out the reward when it dies.
+func (b *BossEncounter) Update() {
+	for _, g := range b.Gates {
+		g.Update()
+	}
+	if b.Defeated {
+		return
+	}
+
+	p := &gameobjects.PlayerInstance
+	left, right := b.Gates[0], b.Gates[1]
+	minX := left.Position.X + left.Width + p.Width/2
+	maxX := right.Position.X - p.Width/2
+
+	if !b.Active {
+		if spawner.Wave >= bossUnlockWave && p.Position.X > minX+50 && p.Position.X < maxX {
+			b.start()
+		}
+		return
+	}
+
+	// Locked in
+	p.Position.X = clampFloat(p.Position.X, minX, maxX)
+
+	if n := b.Boss.PendingSummons; n > 0 {
+		b.Boss.PendingSummons = 0
+		spawner.SpawnMinions(n, b.Boss.Position.X)
+	}
+
+	if !b.Boss.IsAlive {
+		b.finish()
+	}
+}
+
+func (b *BossEncounter) start() {
+	log.Println("Boss fight!")
+	for _, g := range b.Gates {
+		g.Close()
+	}
+	z := gameobjects.NewBoss(arenaRight-250, float32(worldHeight)-50)
+	z.Nav = navGraph
+	zombies = append(zombies, &z)
+	b.Boss = &z
+	b.Active = true
+	spawner.Paused = true
+}
+
+func (b *BossEncounter) finish() {
+	log.Println("Boss defeated")
+	b.Active = false
+	b.Defeated = true
+	for _, g := range b.Gates {
+		g.TryUnlock()
+	}
+	spawner.Paused = false
+	// The reward comes from the boss loot table once the corpse is cleared
+}
+
+// DrawGates draws the arena gates (world space).
+func (b *BossEncounter) DrawGates() {
+	for _, g := range b.Gates {
+		g.Draw()
+	}
+}
+
+// DrawHealthBar draws the big boss bar along the bottom of the screen, above
+// the hotbar, with a tick at every phase threshold.
+func (b *BossEncounter) DrawHealthBar() {
+	const (
+		barW   = 500
+		barH   = 18
+		bottom = 70 // clears the hotbar
+	)
+	x, y := ui.Place(ui.BottomCenter, barW, barH, 0, bottom, screenWidth, screenHeight)
+	frac := float32(b.Boss.Health) / float32(b.Boss.MaxHealth)
+
+	rl.DrawText(bossName, x, y-18, 16, rl.White)
+	rl.DrawRectangle(x-2, y-2, barW+4, barH+4, rl.Black)
+	rl.DrawRectangle(x, y, barW, barH, rl.DarkGray)
+	rl.DrawRectangle(x, y, int32(barW*frac), barH, rl.Purple)
+	for _, t := range []float32{0.66, 0.33} {
+		tx := x + int32(barW*t)
+		rl.DrawLine(tx, y, tx, y+barH, rl.White)
+	}
+	phaseText := fmt.Sprintf("Phase %d", b.Boss.Mind.Phase+1)
+	rl.DrawText(phaseText, x+barW-rl.MeasureText(phaseText, 12), y-14, 12, rl.LightGray)
+}
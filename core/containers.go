@@ -0,0 +1,95 @@
+package core
+
+import (
+	"log"
+	"platformer-game/database"
+	"platformer-game/gameobjects"
+	"platformer-game/level"
+)
+
+var (
+	containers    map[SceneID][]*gameobjects.Container
+	openContainer *gameobjects.Container // the one whose grid is showing, or nil
+)
+
+// buildContainers creates the containers described in a level, restoring
+// saved contents or filling in their starting items the first time. A
+// container with a key starts locked unless the game saved it unlocked.
+func buildContainers(l *level.Level) []*gameobjects.Container {
+	var out []*gameobjects.Container
+	for _, def := range l.Containers {
+		c := gameobjects.NewContainer(def.ID, def.Name, def.X, l.Ground, def.Slots)
+		c.RequiredKey = def.Key
+		c.Locked = def.Key != "" && !database.Unlocked("container", def.ID)
+		if !c.LoadFromDB(itemTextures) {
+			for _, name := range def.Items {
+				c.Inventory.AddItem(newItem(name))
+			}
+			c.SaveToDB()
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// interactWithContainer handles E at a container: close it if it's open,
+// otherwise unlock it (with the key) and open it. Returns true if the player
+// was at a container.
+func interactWithContainer() bool {
+	p := &gameobjects.PlayerInstance
+	for _, c := range containers[currentScene] {
+		if !c.PlayerNear(p.Position, p.Width, p.Height) {
+			continue
+		}
+		if c == openContainer {
+			closeContainer()
+			return true
+		}
+		if c.Locked {
+			if !p.Inventory.HasItem(c.RequiredKey) {
+				log.Printf("The %s is locked.\n", c.Name)
+				return true
+			}
+			log.Printf("Unlocked the %s with the %s\n", c.Name, c.RequiredKey)
+			c.Locked = false
+			database.SaveUnlocked("container", c.ID)
+		}
+		closeContainer()
+		closeShop()
+		if bench.IsOpen {
+			bench.Toggle()
+		}
+		c.IsOpen = true
+		openContainer = c
+		p.Inventory.IsOpen = true
+		return true
+	}
+	return false
+}
+
+// updateOpenContainer closes the open container once the player walks away,
+// closes the inventory or leaves the scene.
+func updateOpenContainer() {
+	if openContainer == nil {
+		return
+	}
+	p := &gameobjects.PlayerInstance
+	if !p.Inventory.IsOpen || fading || !openContainer.PlayerNear(p.Position, p.Width, p.Height) {
+		closeContainer()
+	}
+}
+
+func closeContainer() {
+	if openContainer == nil {
+		return
+	}
+	openContainer.IsOpen = false
+	openContainer = nil
+}
+
+// drawContainers draws the containers of the current scene (world space).
+func drawContainers() {
+	for _, c := range containers[currentScene] {
+		c.Draw()
+	}
+}
@@ -0,0 +1,33 @@
+package core
+
+import (
+	"log"
+	"platformer-game/config"
+	"platformer-game/crafting"
+	"platformer-game/gameobjects"
+)
+
+const recipesFile = "recipes.json" // under the asset root
+
+var bench *crafting.Bench
+
+func initCrafting() {
+	recipes, err := crafting.LoadRecipes(config.Asset(recipesFile))
+	if err != nil {
+		log.Println("Failed to load recipes:", err)
+	}
+	bench = crafting.NewBench(recipes, newItem)
+	bench.LoadFromDB()
+	bench.OnCrafted = onItemPickedUp
+}
+
+// toggleCrafting opens the crafting panel (with the inventory beside it) or
+// closes it. It shares the right-hand side of the screen with containers.
+func toggleCrafting() {
+	bench.Toggle()
+	if bench.IsOpen {
+		closeContainer()
+		closeShop()
+		gameobjects.PlayerInstance.Inventory.IsOpen = true
+	}
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"log"
+	"platformer-game/config"
+	"platformer-game/database"
+	"platformer-game/dialogue"
+	"platformer-game/gameobjects"
+	"platformer-game/input"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	dialogueDir = "dialogue" // under the asset root
+	talkRange   = 100        // how close the player has to be to talk to an NPC
+)
+
+// itemDialogues plays a script the first time an item is picked up.
+var itemDialogues = map[string]string{
+	"BronzeKey": "bronze_key",
+}
+
+var (
+	dialogues    map[string]*dialogue.Script
+	conversation *dialogue.Runner
+	nearDoor     = map[*gameobjects.Door]bool{} // player standing at a locked door last frame
+)
+
+// gameWorld lets dialogue scripts read and change the game.
+type gameWorld struct{}
+
+func (gameWorld) HasItem(name string) bool {
+	return gameobjects.PlayerInstance.Inventory.HasItem(name)
+}
+
+func (gameWorld) GiveItem(name string) {
+	it := newItem(name)
+	inv := &gameobjects.PlayerInstance.Inventory
+	if !inv.AddItem(it) {
+		log.Println("Inventory full! Couldn't receive", name)
+		return
+	}
+	log.Println("Received:", name)
+	inv.SaveToDB()
+}
+
+func (gameWorld) TakeItem(name string) {
+	gameobjects.PlayerInstance.Inventory.RemoveItem(name)
+}
+
+func (gameWorld) UnlockDoor(id string) {
+	for _, d := range append(append([]*gameobjects.Door{}, doors...), insideDoors...) {
+		if d.ID == id {
+			unlockDoor(d)
+		}
+	}
+}
+
+// initDialogue loads the conversations and the story flags saved with the
+// game; flags the conversations set or clear from now on are saved too.
+func initDialogue() {
+	dialogues = dialogue.LoadScripts(config.Asset(dialogueDir))
+	conversation = dialogue.NewRunner(gameWorld{})
+	conversation.Flags = database.LoadFlags()
+	conversation.OnFlag = database.SaveFlag
+}
+
+// startDialogue begins the script with the given ID, if it exists.
+func startDialogue(id string) {
+	s, ok := dialogues[id]
+	if !ok {
+		log.Println("No dialogue script named", id)
+		return
+	}
+	conversation.Start(s)
+}
+
+// startItemDialogue plays the pickup script for an item, if it has one.
+func startItemDialogue(name string) {
+	if id, ok := itemDialogues[name]; ok {
+		startDialogue(id)
+	}
+}
+
+// checkDialogueTriggers starts conversations from the world: T next to the
+// companion, or walking up to a locked door.
+func checkDialogueTriggers() {
+	p := &gameobjects.PlayerInstance
+	if currentScene != SceneOutside {
+		return
+	}
+
+	if input.Pressed(input.Talk) && !companion.Downed &&
+		rl.Vector2Distance(p.Position, companion.Body.Position) <= talkRange {
+		startDialogue("sam")
+		return
+	}
+
+	for _, d := range doors {
+		at := d.Locked && !p.Inventory.HasItem(d.RequiredKey) &&
+			d.PlayerNear(p.Position, p.Width, p.Height)
+		if at && !nearDoor[d] {
+			startDialogue("locked_door")
+		}
+		nearDoor[d] = at
+	}
+}
@@ -0,0 +1,169 @@
+package core
+
+import (
+	"log"
+	"platformer-game/ai"
+	"platformer-game/config"
+	"platformer-game/database"
+	"platformer-game/gameobjects"
+	"platformer-game/level"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// sceneNames maps the scene names used in level data to scene IDs.
+var sceneNames = map[string]SceneID{
+	"outside": SceneOutside,
+	"inside":  SceneInside,
+}
+
+var (
+	insideLevel  *level.Level      // the house interior
+	leavingDoor  *gameobjects.Door // door the player is walking through while fading
+	arrivalSpawn float32           // where the player appears after the fade
+)
+
+// buildDoors creates the doors described in a level, standing on its ground.
+// A door with a key starts locked unless the game saved it unlocked.
+func buildDoors(l *level.Level) []*gameobjects.Door {
+	var out []*gameobjects.Door
+	for _, def := range l.Doors {
+		d := gameobjects.NewAnimatedDoor(def.ID, def.X, l.Ground-128, config.Asset(doorSheet), doorRects, 100)
+		d.RequiredKey = def.Key
+		d.Locked = def.Key != "" && !database.Unlocked("door", def.ID)
+		d.Destination = def.To
+		d.SpawnX = def.SpawnX
+		d.OnLeaveClicked = func() { travel(d) }
+		out = append(out, d)
+	}
+	return out
+}
+
+// unlockDoor unlocks d and opens it, and saves it unlocked.
+func unlockDoor(d *gameobjects.Door) {
+	d.TryUnlock()
+	database.SaveUnlocked("door", d.ID)
+}
+
+// sceneLevel returns the geometry of a scene.
+func sceneLevel(scene SceneID) *level.Level {
+	if scene == SceneInside {
+		return insideLevel
+	}
+	return outsideLevel
+}
+
+// sceneDoors returns the doors of the given scene.
+func sceneDoors(scene SceneID) []*gameobjects.Door {
+	if scene == SceneInside {
+		return insideDoors
+	}
+	return doors
+}
+
+// updateDoors animates every door (including ones in the scene we're not in,
+// so a door left behind finishes closing) and reports doors that just opened.
+func updateDoors() {
+	for _, list := range [][]*gameobjects.Door{doors, insideDoors} {
+		for _, d := range list {
+			if d.Update() {
+				quests.DoorOpened(d.ID)
+			}
+		}
+	}
+}
+
+// interactWithDoor handles E at the door the player is standing at:
+// unlock it (with the key), open it, or walk through once it's open.
+func interactWithDoor() {
+	p := &gameobjects.PlayerInstance
+	for _, d := range sceneDoors(currentScene) {
+		if !d.PlayerNear(p.Position, p.Width, p.Height) {
+			continue
+		}
+		switch {
+		case d.Locked && p.Inventory.HasItem(d.RequiredKey):
+			log.Printf("Unlocked %s with the %s\n", d.ID, d.RequiredKey)
+			unlockDoor(d)
+		case d.Locked:
+			startDialogue("locked_door")
+		case d.State == gameobjects.DoorClosed || d.State == gameobjects.DoorClosing:
+			d.Open()
+		case d.State == gameobjects.DoorOpen:
+			travel(d)
+		}
+		return
+	}
+}
+
+// handleDoorMouse wires up the right-click "Leave" menu of the doors in the
+// current scene.
+func handleDoorMouse() {
+	p := &gameobjects.PlayerInstance
+	for _, d := range sceneDoors(currentScene) {
+		d.HandleMouse(p.Position, p.Width, p.Height, cam.Camera2D())
+	}
+}
+
+// drawDoorMenus draws any open door context menu (screen space).
+func drawDoorMenus() {
+	for _, d := range sceneDoors(currentScene) {
+		d.DrawContextMenu()
+	}
+}
+
+// travel starts the fade to d's destination scene.
+func travel(d *gameobjects.Door) {
+	to, ok := sceneNames[d.Destination]
+	if !ok {
+		log.Printf("Door %s leads to unknown scene %q\n", d.ID, d.Destination)
+		return
+	}
+	if fading {
+		return
+	}
+	leavingDoor = d
+	arrivalSpawn = d.SpawnX
+	targetScene = to
+	fading = true
+	fadeDir = -1
+}
+
+// arrive places the player in the new scene; called at the black point of the fade.
+func arrive() {
+	p := &gameobjects.PlayerInstance
+	p.Position = rl.NewVector2(arrivalSpawn, float32(worldHeight)-55)
+	cam.Bounds = sceneLevel(currentScene).Bounds()
+	cam.Snap(p.Position)
+
+	// The door behind us swings shut
+	if leavingDoor != nil {
+		leavingDoor.StartClosing()
+	}
+
+	// So does the one we come out of, if we come out next to a door
+	for _, d := range sceneDoors(currentScene) {
+		center := d.Position.X + d.Width/2
+		if center > arrivalSpawn-150 && center < arrivalSpawn+150 {
+			if d.Locked { // it opens from this side
+				d.Locked = false
+				database.SaveUnlocked("door", d.ID)
+			}
+			d.SetOpen()
+			d.StartClosing()
+		}
+	}
+
+	if currentScene == SceneInside {
+		zombies = nil
+		horde = ai.NewHorde(hordeMaxAttackers, hordeAlertRadius)
+		// The companion keeps watch outside the door
+		if leavingDoor != nil {
+			companion.WaitAt(leavingDoor.Position.X - 60)
+		}
+	} else {
+		spawner.RestartWave()
+		companion.Follow()
+	}
+	leavingDoor = nil
+}
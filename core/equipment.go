@@ -0,0 +1,33 @@
+package core
+
+import "platformer-game/gameobjects"
+
+// gearDefs is every item that can be worn, and what it does.
+var gearDefs = map[string]gameobjects.Gear{
+	"Helmet": {
+		Slot:          gameobjects.SlotHead,
+		Stats:         gameobjects.Stats{Armor: 0.15},
+		MaxDurability: 25,
+	},
+	"Vest": {
+		Slot:          gameobjects.SlotBody,
+		Stats:         gameobjects.Stats{Armor: 0.25, MaxHealth: 25},
+		MaxDurability: 40,
+	},
+	"RunningShoes": {
+		Slot:          gameobjects.SlotAccessory,
+		Stats:         gameobjects.Stats{MoveSpeed: 0.25},
+		MaxDurability: 60,
+	},
+	"Bandolier": {
+		Slot:          gameobjects.SlotAccessory,
+		Stats:         gameobjects.Stats{ReloadSpeed: 0.5},
+		MaxDurability: 60,
+	},
+}
+
+// initEquipment registers the gear and puts back on whatever was worn last time.
+func initEquipment() {
+	gameobjects.GearDefs = gearDefs
+	gameobjects.PlayerInstance.LoadEquipmentFromDB(itemTextures)
+}
@@ -4,16 +4,31 @@ import (
 	"fmt"
 	rl "github.com/gen2brain/raylib-go/raylib"
 	"log"
-	"math/rand"
+	"platformer-game/ai"
+	"platformer-game/camera"
+	"platformer-game/clock"
+	"platformer-game/config"
 	"platformer-game/database"
 	"platformer-game/gameobjects"
-	"time"
+	"platformer-game/input"
+	"platformer-game/level"
+	"platformer-game/nav"
+	"platformer-game/rendering"
+	"platformer-game/ui"
 )
 
 var (
-	camera     rl.Camera2D
+	cam        *camera.Camera // follows the player; its Camera2D is what the world is drawn through
 	background rl.Texture2D
 	zombies    []*gameobjects.Zombie
+	horde      *ai.Horde // lets zombies share sightings and take turns attacking
+	spawner    *Spawner  // feeds zombies into the outside scene wave by wave
+
+	outsideLevel *level.Level           // ground and ledges of the street
+	navGraph     *nav.Graph             // zombie navigation graph built from outsideLevel
+	mice         []*gameobjects.Mouse   // critters placed from outsideLevel.MouseSpawns
+	companion    *gameobjects.Companion // follows the player around outside
+	showNavDebug bool                   // F3 toggles drawing the nav graph and zombie paths
 )
 
 // core/game.go (at top)
@@ -33,39 +48,78 @@ const (
 const (
 	worldWidth   = 5000
 	worldHeight  = 1200
-	screenWidth  = 800
-	screenHeight = 450
+	screenWidth  = rendering.ScreenWidth // the virtual screen everything is laid out on
+	screenHeight = rendering.ScreenHeight
 )
 
 const (
 	miniMapWidth  = 200
 	miniMapHeight = 150
-	miniMapX      = screenWidth - miniMapWidth - 10
-	miniMapY      = 10
-	deadZoneWidth = 200
+	miniMapMargin = 10 // from the top-right corner
+)
+
+const (
+	hordeMaxAttackers = 2     // zombies allowed to swing at the player at once
+	hordeAlertRadius  = 400.0 // how far a zombie's sighting spreads
 )
 
 // We now have two world items: one Sword and one Health Pack.
 var (
-	testItem    gameobjects.WorldItem
-	testItem2   gameobjects.WorldItem
-	testItem3   gameobjects.WorldItem // For the BronzeKey
-	doors       []*gameobjects.Door   // ← add this
-	insideDoors []*gameobjects.Door
-	fadeAlpha   float32 = 0 // 0 = fully transparent, 1 = fully black
-	fading      bool    = false
-	fadeDir     float32 = 0            // −1 = fade out, +1 = fade in
-	targetScene SceneID = SceneOutside // where we want to go after fading
+	testItem     gameobjects.WorldItem
+	testItem2    gameobjects.WorldItem
+	testItem3    gameobjects.WorldItem        // For the BronzeKey
+	doors        []*gameobjects.Door          // doors of the outside scene
+	droppedItems []*gameobjects.WorldItem     // loot spawned at runtime (boss rewards, ...)
+	itemTextures map[string]rl.Texture2D      // every item texture, by item name
+	insideDoors  []*gameobjects.Door          // doors of the house interior
+	fadeAlpha    float32                  = 0 // 0 = fully transparent, 1 = fully black
+	fading       bool                     = false
+	fadeDir      float32                  = 0            // −1 = fade out, +1 = fade in
+	targetScene  SceneID                  = SceneOutside // where we want to go after fading
 )
 
-func InitGame(worldW, worldH int) {
+// Door spritesheet and the frames to cut from it (closed → open); shared by
+// the house door and the boss arena gates.
+const doorSheet = "sprites/doors_spritesheet.png"
+
+var doorRects = []rl.Rectangle{
+	{X: 19, Y: 59, Width: 78, Height: 130},  // frame 0 = closed
+	{X: 118, Y: 59, Width: 78, Height: 130}, // frame 1
+	{X: 218, Y: 59, Width: 77, Height: 133}, // frame 2
+	{X: 317, Y: 54, Width: 77, Height: 142}, // frame 3
+	{X: 416, Y: 49, Width: 78, Height: 153}, // frame 4
+	{X: 515, Y: 49, Width: 78, Height: 152}, // frame 5 = fully open
+}
+
+// startScenes are the levels a game can start in, by their config name.
+var startScenes = map[string]SceneID{
+	"outside": SceneOutside,
+	"inside":  SceneInside,
+}
+
+var startScene = SceneOutside // where startWorld puts the player
+
+// cameraConfig tunes the camera; InitGame takes the zoom from the config.
+var cameraConfig = camera.DefaultConfig()
+
+// InitGame opens the window and sets up everything the config asks for.
+func InitGame(cfg config.Config) {
+	openWindow(cfg)
+	config.AssetRoot = cfg.AssetRoot
+	database.Path = cfg.SavePath
+	cameraConfig.Zoom = float32(cfg.Zoom)
+	if s, ok := startScenes[cfg.StartLevel]; ok {
+		startScene = s
+	} else {
+		log.Printf("Unknown start level %q, starting outside\n", cfg.StartLevel)
+	}
+
 	// 1) Load a background texture
-	background = rl.LoadTexture("assets/background2.png")
-	doorSheet := "assets/sprites/doors_spritesheet.png"
+	background = rl.LoadTexture(config.Asset("background2.png"))
 
 	// Load outside and inside backgrounds exactly once:
-	outsideBG = rl.LoadTexture("assets/levelonebg.png")
-	insideBG = rl.LoadTexture("assets/background2.png")
+	outsideBG = rl.LoadTexture(config.Asset("levelonebg.png"))
+	insideBG = rl.LoadTexture(config.Asset("background2.png"))
 	src := rl.NewRectangle(0, 0, float32(insideBG.Width), float32(insideBG.Height))
 	dest := rl.NewRectangle(0, 0, float32(screenWidth), float32(screenHeight))
 	origin := rl.NewVector2(0, 0)
@@ -77,119 +131,231 @@ func InitGame(worldW, worldH int) {
 	currentScene = SceneOutside
 
 	// 2) Initialize the player (sets up PlayerInstance with default health, inventory, etc.)
-	gameobjects.InitPlayer(worldW, worldH)
+	gameobjects.InitPlayer(worldWidth, worldHeight)
 
-	// 3) Open (or create) our SQLite database
+	// 3) Open (or create) our SQLite database; Continue needs one from an earlier run.
+	//    Recording or replaying a run swaps in a scratch database first.
+	initReplay(cfg)
+	canContinue = database.Exists()
 	database.InitDatabase()
+	loadSettings()
+	applySettings()
+	applyWindowConfig(cfg)
+	if headless {
+		rl.SetMasterVolume(0)
+	}
 
 	// 4) Preload all item textures by name
-	itemTextures := map[string]rl.Texture2D{
-		"Sword":      rl.LoadTexture("assets/sword.png"),
-		"HealthPack": rl.LoadTexture("assets/healthpack.png"),
-		"BronzeKey":  rl.LoadTexture("assets/bronze_key.png"), // or whichever key sprite
+	itemTextures = map[string]rl.Texture2D{
+		"Sword":        rl.LoadTexture(config.Asset("sword.png")),
+		"HealthPack":   rl.LoadTexture(config.Asset("healthpack.png")),
+		"BronzeKey":    rl.LoadTexture(config.Asset("bronze_key.png")), // or whichever key sprite
+		"AmmoBox":      rendering.PlaceholderIcon(48, rl.DarkGreen, "AMMO"),
+		"Bandage":      rendering.PlaceholderIcon(48, rl.Beige, "BND"),
+		"Grenade":      rendering.PlaceholderIcon(48, rl.DarkGray, "GRN"),
+		"Scrap":        rendering.PlaceholderIcon(48, rl.Gray, "SCR"),
+		"Cloth":        rendering.PlaceholderIcon(48, rl.Maroon, "CLO"),
+		"Powder":       rendering.PlaceholderIcon(48, rl.Black, "PWD"),
+		"Can":          rendering.PlaceholderIcon(48, rl.LightGray, "CAN"),
+		"Helmet":       rendering.PlaceholderIcon(48, rl.DarkGreen, "HLM"),
+		"Vest":         rendering.PlaceholderIcon(48, rl.DarkBlue, "VST"),
+		"RunningShoes": rendering.PlaceholderIcon(48, rl.Red, "SHO"),
+		"Bandolier":    rendering.PlaceholderIcon(48, rl.DarkBrown, "BDL"),
+		coinItem:       rendering.PlaceholderIcon(24, rl.Gold, "$"),
+	}
 
+	initItems()
+
+	startWorld()
+	if cfg.Record != "" {
+		startRecording(cfg.Record)
 	}
+}
 
-	// 5) Load whatever was saved in the "inventory" table:
+// Shutdown finishes anything still being written, e.g. a recording, and
+// closes the window.
+func Shutdown() {
+	stopRecording()
+	rl.CloseWindow()
+}
+
+// startWorld (re)builds everything a game is made of from the database: the
+// player's things, doors, containers, quests, shops and so on. The title
+// screen calls it again for New Game and Load Game.
+func startWorld() {
+	worldW, worldH := worldWidth, worldHeight
+	stopRecording() // a recording covers one run
+	seedRun()
+	closeContainer()
+	closeShop()
+	for _, z := range zombies {
+		z.UnloadSounds()
+	}
+	zombies = nil
+	for _, m := range mice {
+		m.Unload()
+	}
+	mice = nil
+	droppedItems = nil
+	currentScene = startScene
+	fading, fadeAlpha, leavingDoor = false, 0, nil
+	nearDoor = map[*gameobjects.Door]bool{}
+	questBannerTimer = 0
+	stats, deathHold = runStats{}, 0
+
+	// 5) Load whatever was saved in the "inventory" and "equipment" tables,
+	//    and the hotbar bindings from the settings:
+	gameobjects.PlayerInstance.Reset(worldH)
 	gameobjects.PlayerInstance.Inventory.LoadFromDB(itemTextures)
+	restoreHotbar()
+	initEquipment()
 
 	// 6) Spawn two WorldItems in the scene:
 	//    - Sword at (110, 1040)
 	//    - HealthPack at (200, 1040)
-	doorRects := []rl.Rectangle{
-		{X: 19, Y: 59, Width: 78, Height: 130},  // frame 0 = closed
-		{X: 118, Y: 59, Width: 78, Height: 130}, // frame 1
-		{X: 218, Y: 59, Width: 77, Height: 133}, // frame 2
-		{X: 317, Y: 54, Width: 77, Height: 142}, // frame 3
-		{X: 416, Y: 49, Width: 78, Height: 153}, // frame 4
-		{X: 515, Y: 49, Width: 78, Height: 152}, // frame 5 = fully open
-	}
-
-	doors = append(doors, gameobjects.NewAnimatedDoor(
-		"BronzeKey", // that same key name from your inventory logic
-		1200, float32(worldHeight-128),
-		doorSheet,
-		doorRects,
-		100, // 100ms between frames
-	))
-
 	testItem = gameobjects.NewWorldItem(
 		110, 1040,
 		gameobjects.Weapon,
 		"Sword",
-		"assets/sword.png",
+		config.Asset("sword.png"),
 	)
 	testItem2 = gameobjects.NewWorldItem(
 		200, 1100,
 		gameobjects.HealthPack,
 		"HealthPack",
-		"assets/healthpack.png",
+		config.Asset("healthpack.png"),
 	)
 	testItem3 = gameobjects.NewWorldItem(
 		300, worldHeight-100,
-		gameobjects.KeyType, "BronzeKey", "assets/bronze_key.png",
+		gameobjects.KeyType, "BronzeKey", config.Asset("bronze_key.png"),
 	)
 
-	// 7) Spawn some zombies
-	initZombies(5)
+	// 7) Build the outside level's nav graph, then set up the wave spawner (the first wave arrives after a short intermission)
+	outsideLevel = level.Outside(worldW, worldH)
+	insideLevel = level.Inside(worldW, worldH)
+	doors = buildDoors(outsideLevel)
+	insideDoors = buildDoors(insideLevel)
+	containers = map[SceneID][]*gameobjects.Container{
+		SceneOutside: buildContainers(outsideLevel),
+		SceneInside:  buildContainers(insideLevel),
+	}
+	navGraph = nav.Build(outsideLevel.Surfaces(), nav.DefaultConfig())
+	for _, x := range outsideLevel.MouseSpawns {
+		m := gameobjects.NewMouse(x, 0)
+		m.Position.Y = outsideLevel.Ground - m.Height
+		mice = append(mice, m)
+	}
+	horde = ai.NewHorde(hordeMaxAttackers, hordeAlertRadius)
+	spawner = NewSpawner(defaultWaves, maxAliveZombies)
+	bossFight = NewBossEncounter()
+	initDialogue()
+	initQuests()
+	initCrafting()
+	initShops()
+	companion = gameobjects.NewCompanion("Sam", gameobjects.PlayerInstance.Position.X-100, float32(worldHeight)-50)
+	gameobjects.ActiveCompanion = companion
+	if currentScene != SceneOutside {
+		companion.WaitAt(companion.Body.Position.X) // Sam stays out on the street
+	}
 
 	// 8) Set up a 2D camera that follows the player
-	camera = rl.Camera2D{
-		Target: gameobjects.PlayerInstance.Position,
-		Offset: rl.NewVector2(float32(screenWidth)/2, float32(screenHeight)/2),
-		Zoom:   1.0,
-	}
+	cam = camera.New(cameraConfig, screenWidth, screenHeight, sceneLevel(currentScene).Bounds())
+	cam.Snap(gameobjects.PlayerInstance.Position)
 }
 
-// initZombies places `numZombies` zombies at random x-positions along the ground.
-func initZombies(numZombies int) {
-	rand.Seed(time.Now().UnixNano())
-	for i := 0; i < numZombies; i++ {
-		x := float32(rand.Intn(worldWidth-100) + 50)
-		y := float32(worldHeight) // Keep them at ground level
-		z := gameobjects.InitZombie(x, y-50, 1)
-		zombies = append(zombies, &z)
+func UpdateGame() {
+	inv := &gameobjects.PlayerInstance.Inventory
+	canvas.Layout() // the window may have been resized; the mouse reads from the canvas
+	if playbackOver() {
+		quit = true
+		return
 	}
-}
 
-func UpdateGame(worldH int) {
-	inv := &gameobjects.PlayerInstance.Inventory
+	// 0) The menus freeze the world, and so does the player's death (until the
+	//    game over screen comes up) or a conversation (until it's finished).
+	//    Everything after the menus is one tick of the world: game time only
+	//    moves on, and a recording only grows, from here.
+	if updateMenus() {
+		return
+	}
+	beginTick()
+	dt := clock.Delta()
+	if gameobjects.PlayerInstance.IsGameOver() {
+		updateDeath(dt)
+		updateCamera(dt) // lets the shake of the killing blow die down
+		return
+	}
+	stats.Time += dt
+	if conversation.Active() {
+		conversation.Update(dt)
+		return
+	}
 
-	// 1) Let the inventory handle mouse/keyboard (drag/drop, context menu, etc.)
-	inv.HandleMouse()
+	// 1) Let the inventory handle mouse/keyboard (drag/drop, context menu, etc.);
+	//    with a container open, drag/drop works across both grids instead.
+	//    ESC closes whatever panel is open (see updateMenus).
+	if input.Pressed(input.Back) {
+		closePanels()
+	}
+	if openContainer != nil {
+		gameobjects.HandleTransfer(inv, &openContainer.Inventory)
+	} else if openShop != nil {
+		updateOpenShop()
+	} else if !inv.IsOpen || !inv.HandleWindow() {
+		inv.HandleMouse()
+		if inv.IsOpen {
+			gameobjects.PlayerInstance.HandleEquipmentMouse()
+		}
+	}
+	updateInventoryKeys()
+	gameobjects.PlayerInstance.UpdateHotbar()
 
 	// 2) If the player just used a key, attempt to unlock the matching door
 	if keyID := gameobjects.PlayerInstance.UsedKeyID; keyID != "" {
-		if currentScene == SceneOutside {
-			for _, d := range doors {
-				if d.ID == keyID {
-					d.TryUnlock()
-					break
-				}
-			}
-		} else /* SceneInside */ {
-			for _, d := range insideDoors {
-				if d.ID == keyID {
-					d.TryUnlock()
-					break
-				}
+		for _, d := range sceneDoors(currentScene) {
+			if d.RequiredKey == keyID {
+				unlockDoor(d)
+				break
 			}
 		}
 		gameobjects.PlayerInstance.UsedKeyID = ""
 	}
 
-	// 3) Toggle inventory on/off with "I"
-	if rl.IsKeyPressed(rl.KeyI) {
+	// 2b) Doors: E to unlock/open/walk through, right-click an open door to "Leave".
+	//     Containers: E to open/close.
+	handleDoorMouse()
+	if input.Pressed(input.Interact) && !fading && !interactWithContainer() && !interactWithMerchant() {
+		interactWithDoor()
+	}
+	updateOpenContainer()
+
+	// 3) Toggle inventory on/off with "I", the quest log with "J" (or whatever they're bound to)
+	if input.Pressed(input.Inventory) {
 		inv.IsOpen = !inv.IsOpen
 	}
+	if input.Pressed(input.QuestLog) {
+		quests.IsOpen = !quests.IsOpen
+	}
+	if input.Pressed(input.Crafting) {
+		toggleCrafting()
+	}
+	if bench.IsOpen && !inv.IsOpen {
+		bench.Toggle() // closing the inventory puts the crafting away too
+	}
+	bench.Update(inv, dt)
+	updateShops(dt)
 	if rl.IsKeyPressed(rl.KeyK) {
-		background = rl.LoadTexture("assets/background2.png")
+		background = rl.LoadTexture(config.Asset("background2.png"))
+	}
+	if rl.IsKeyPressed(rl.KeyF3) {
+		showNavDebug = !showNavDebug
 	}
 
 	playerPos := gameobjects.PlayerInstance.Position
+	checkDialogueTriggers()
 
 	// 4) If we're outside, handle "E" to pick up world items
-	if currentScene == SceneOutside && rl.IsKeyPressed(rl.KeyE) {
+	if currentScene == SceneOutside && input.Pressed(input.Interact) {
 		// Sword pickup
 		if testItem.Texture.ID != 0 && rl.Vector2Distance(playerPos, testItem.Position) < 50 {
 			it := gameobjects.Item{
@@ -201,6 +367,7 @@ func UpdateGame(worldH int) {
 				log.Println("Picked up:", it.Name)
 				testItem.Texture.ID = 0
 				gameobjects.PlayerInstance.Inventory.SaveToDB()
+				onItemPickedUp(it.Name)
 			} else {
 				log.Println("Inventory full!")
 			}
@@ -216,10 +383,18 @@ func UpdateGame(worldH int) {
 				log.Println("Picked up:", it2.Name)
 				testItem2.Texture.ID = 0
 				gameobjects.PlayerInstance.Inventory.SaveToDB()
+				onItemPickedUp(it2.Name)
 			} else {
 				log.Println("Inventory full!")
 			}
 		}
+		// Runtime drops
+		for i := len(droppedItems) - 1; i >= 0; i-- {
+			w := droppedItems[i]
+			if rl.Vector2Distance(playerPos, w.Position) < 50 && pickUpWorldItem(w) {
+				droppedItems = append(droppedItems[:i], droppedItems[i+1:]...)
+			}
+		}
 		// BronzeKey pickup
 		if testItem3.Texture.ID != 0 && rl.Vector2Distance(playerPos, testItem3.Position) < 50 {
 			it3 := gameobjects.Item{
@@ -231,6 +406,7 @@ func UpdateGame(worldH int) {
 				log.Println("Picked up:", it3.Name)
 				testItem3.Texture.ID = 0
 				gameobjects.PlayerInstance.Inventory.SaveToDB()
+				onItemPickedUp(it3.Name)
 			} else {
 				log.Println("Inventory full!")
 			}
@@ -239,42 +415,23 @@ func UpdateGame(worldH int) {
 
 	// 5) Update player physics/movement/shooting every frame (pass zombies only if outside)
 	if currentScene == SceneOutside {
-		gameobjects.PlayerInstance.Update(worldH, worldWidth, zombies)
+		gameobjects.PlayerInstance.Update(worldHeight, worldWidth, zombies)
 		gameobjects.PlayerInstance.Shoot()
 	} else /* SceneInside */ {
 		// No zombies inside; pass nil
-		gameobjects.PlayerInstance.Update(worldH, worldWidth, nil)
+		gameobjects.PlayerInstance.Update(worldHeight, worldWidth, nil)
 		gameobjects.PlayerInstance.Shoot()
 	}
 
-	// 6) Advance door animations and handle scene‐switch when a door finishes opening
-	// door‐opening fade logic
-	if !fading {
-		// advance each door
-		if currentScene == SceneOutside {
-			for _, d := range doors {
-				d.Update()
-				if d.State == gameobjects.DoorOpen {
-					targetScene = SceneInside
-					fading = true
-					fadeDir = -1
-					break
-				}
-			}
-		} else {
-			for _, d := range insideDoors {
-				d.Update()
-				if d.State == gameobjects.DoorOpen {
-					targetScene = SceneOutside
-					fading = true
-					fadeDir = -1
-					break
-				}
-			}
-		}
+	// 5b) Boss arena: trigger, keep the player locked in, hand out the reward
+	if currentScene == SceneOutside {
+		bossFight.Update()
+		quests.PlayerAt(gameobjects.PlayerInstance.Position)
 	}
+
+	// 6) Advance door animations; walking through a door (see travel) fades to its scene
+	updateDoors()
 	if fading {
-		dt := rl.GetFrameTime()
 		fadeAlpha += fadeDir * dt
 		if fadeAlpha <= 0 {
 			// at black → swap scene
@@ -284,85 +441,124 @@ func UpdateGame(worldH int) {
 			currentScene = targetScene
 
 			// reposition & clear/spawn entities
-			if currentScene == SceneInside {
-				gameobjects.PlayerInstance.Position = rl.NewVector2(
-					doors[0].Position.X+doors[0].Width+10,
-					float32(worldHeight)-55,
-				)
-				zombies = nil
-				// create insideDoors…
-			} else {
-				// back to outside
-				gameobjects.PlayerInstance.Position = rl.NewVector2(
-					insideDoors[0].Position.X-gameobjects.PlayerInstance.Width-10,
-					float32(worldHeight)-55,
-				)
-				initZombies(5)
-			}
+			arrive()
 		} else if fadeAlpha >= 1 {
 			fadeAlpha = 1
 			fading = false
 		}
 	}
 
-	// 7) Update zombies—but only if we're outside
+	// 7) Collect this frame's noise (always drained so it can't pile up inside),
+	//    then update zombies—but only if we're outside
+	noise := gameobjects.DrainNoise()
 	if currentScene == SceneOutside {
+		target := ai.Target{Position: playerPos, Visibility: gameobjects.PlayerInstance.Visibility()}
+		spawner.Update(dt, playerPos.X)
 		for i := len(zombies) - 1; i >= 0; i-- {
 			z := zombies[i]
-			z.Update(worldWidth, playerPos)
+			z.Update(worldWidth, target, noise, dt)
 			if !z.IsAlive &&
 				z.State == gameobjects.ZombieDead &&
 				z.CurrentFrame == len(z.DeadFrames)-1 {
+				horde.Leave(&z.Mind)
+				quests.ZombieKilled(z.Type.String())
+				stats.Kills++
+				dropLoot(z)
 				z.UnloadSounds()
 				zombies = append(zombies[:i], zombies[i+1:]...)
 			}
 		}
+
+		// 7b) Companion: fights, follows, stops at closed doors, revived by holding F
+		blockers := append(append([]*gameobjects.Door{}, doors...), bossFight.Gates...)
+		companion.Update(&gameobjects.PlayerInstance, zombies, blockers, input.Down(input.Revive), worldWidth, dt)
+
+		// 8) Mice scurry away from the player and gunfire (their noise is heard next frame)
+		for _, m := range mice {
+			m.Update(worldWidth, float32(worldHeight), target, noise)
+		}
 	}
 
-	// 9) Camera follows the player with a small dead‐zone, regardless of scene
-	playerX := gameobjects.PlayerInstance.Position.X
-	if playerX > camera.Target.X+float32(screenWidth)/2-deadZoneWidth {
-		camera.Target.X = playerX - float32(screenWidth)/2 + deadZoneWidth
-	} else if playerX < camera.Target.X-float32(screenWidth)/2+deadZoneWidth {
-		camera.Target.X = playerX + float32(screenWidth)/2 - deadZoneWidth
+	// 9) Camera follows the player through its dead zone, regardless of scene
+	updateCamera(dt)
+}
+
+// updateCamera moves the camera after the player and shakes it with this
+// frame's explosions and hits.
+func updateCamera(dt float32) {
+	p := &gameobjects.PlayerInstance
+	cam.AddTrauma(gameobjects.DrainTrauma())
+	cam.Update(p.Position, p.FacingRight, dt)
+}
+
+// pickUpWorldItem moves a world item into the inventory. Returns false if the inventory is full.
+func pickUpWorldItem(w *gameobjects.WorldItem) bool {
+	if w.Amount > 0 {
+		gameobjects.PlayerInstance.AddCoins(w.Amount)
+		stats.Coins += w.Amount
+		log.Printf("Picked up %d coins\n", w.Amount)
+		return true
 	}
-	camera.Target.X = clampFloat(
-		camera.Target.X,
-		float32(screenWidth)/2,
-		float32(worldWidth)-float32(screenWidth)/2,
-	)
-	camera.Target.Y = clampFloat(
-		camera.Target.Y,
-		float32(screenHeight)/2,
-		float32(worldHeight)-float32(screenHeight)/2,
-	)
+	it := gameobjects.Item{
+		Type:       w.Type,
+		Name:       w.Name,
+		Image:      w.Texture,
+		Durability: gearDefs[w.Name].MaxDurability,
+	}
+	if !gameobjects.PlayerInstance.Inventory.AddItem(it) {
+		log.Println("Inventory full!")
+		return false
+	}
+	log.Println("Picked up:", it.Name)
+	gameobjects.PlayerInstance.Inventory.SaveToDB()
+	onItemPickedUp(it.Name)
+	return true
 }
+
 func DrawMiniMap() {
-	rl.DrawRectangle(miniMapX, miniMapY, miniMapWidth, miniMapHeight, rl.LightGray)
+	mx, my := ui.Place(ui.TopRight, miniMapWidth, miniMapHeight, miniMapMargin, miniMapMargin, screenWidth, screenHeight)
+	miniMapX, miniMapY := int(mx), int(my)
+	rl.DrawRectangle(mx, my, miniMapWidth, miniMapHeight, rl.LightGray)
 
 	scaleX := float32(miniMapWidth) / float32(worldWidth)
 	scaleY := float32(miniMapHeight) / float32(worldHeight)
 
-	rl.DrawRectangleLines(miniMapX, miniMapY, miniMapWidth, miniMapHeight, rl.DarkGray)
+	rl.DrawRectangleLines(mx, my, miniMapWidth, miniMapHeight, rl.DarkGray)
 
-	viewX := miniMapX + int((camera.Target.X-float32(screenWidth)/2)*scaleX)
-	viewY := miniMapY + int((camera.Target.Y-float32(screenHeight)/2)*scaleY)
-	viewW := int(float32(screenWidth) * scaleX * 0.8)
-	viewH := int(float32(screenHeight) * scaleY * 0.8)
+	view := cam.View()
+	viewX := miniMapX + int(view.X*scaleX)
+	viewY := miniMapY + int(view.Y*scaleY)
+	viewW := int(view.Width * scaleX)
+	viewH := int(view.Height * scaleY)
 
 	viewX = clamp(viewX, miniMapX, miniMapX+miniMapWidth-viewW)
 	viewY = clamp(viewY, miniMapY, miniMapY+miniMapHeight-viewH)
 	rl.DrawRectangleLines(int32(viewX), int32(viewY), int32(viewW), int32(viewH), rl.Red)
 }
 
+// DrawGame draws the frame on the virtual screen, then scales that to the
+// window.
 func DrawGame() {
-	rl.BeginDrawing()
+	canvas.Begin()
 	rl.ClearBackground(rl.RayWhite)
+	if inGame() {
+		drawWorld()
+	}
+	drawMenus()
+	canvas.End()
+
+	rl.BeginDrawing()
+	canvas.Draw()
+	rl.EndDrawing()
+}
+
+// drawWorld draws the scene, then the HUD and any open panels on top.
+func drawWorld() {
 	playerPos := gameobjects.PlayerInstance.Position
 	log.Printf("Player Position: (%.2f, %.2f)", playerPos.X, playerPos.Y)
 
 	// ─── 1) Draw the correct world background (under the camera) ───
-	rl.BeginMode2D(camera)
+	rl.BeginMode2D(cam.Camera2D())
 	if currentScene == SceneOutside {
 		DrawWorldBG(outsideBG)
 
@@ -377,17 +573,34 @@ func DrawGame() {
 			testItem3.Draw()
 		}
 
+		// ─── Draw ledges (and the nav graph when debugging) ───
+		outsideLevel.DrawPlatforms()
+		if showNavDebug {
+			navGraph.DrawDebug()
+		}
+
 		// ─── Draw all doors (locked or open) ───
 		for _, d := range doors {
 			d.Draw()
 		}
+		bossFight.DrawGates()
+		for _, w := range droppedItems {
+			w.Draw()
+		}
 
 		// 3) Draw player (including any equipped item)
 		gameobjects.PlayerInstance.Draw()
 
-		// 4) Draw all zombies
+		// 4) Draw the mice, then all zombies
+		for _, m := range mice {
+			m.Draw()
+		}
+		companion.Draw()
 		for _, z := range zombies {
 			z.Draw()
+			if showNavDebug {
+				nav.DrawPath(z.Feet(), z.Path)
+			}
 		}
 	} else {
 		DrawWorldBG(insideBG)
@@ -402,31 +615,52 @@ func DrawGame() {
 	}
 
 	// ─── 2) Now draw your world under the camera ───
-	rl.BeginMode2D(camera)
+	rl.BeginMode2D(cam.Camera2D())
 	if currentScene == SceneOutside {
 		// … draw outside items, doors, zombies …
 	} else {
-		for _, d := range doors {
+		for _, d := range insideDoors {
 			d.Draw()
 		}
-		// … draw inside doors …
 	}
 
+	drawContainers()
+	drawMerchants()
+
 	// ─── 3) Draw the player (always) ───
 	gameobjects.PlayerInstance.Draw()
 	rl.EndMode2D()
 
 	// ─── 4) UI & inventory ───
 	if gameobjects.PlayerInstance.Inventory.IsOpen {
+		gameobjects.PlayerInstance.Inventory.DrawWindow("Inventory")
 		gameobjects.PlayerInstance.Inventory.DrawInventory()
+		gameobjects.PlayerInstance.DrawEquipment()
+	}
+	if openContainer != nil {
+		openContainer.DrawContents(&gameobjects.PlayerInstance.Inventory)
+	}
+	drawShop()
+	bench.Draw(&gameobjects.PlayerInstance.Inventory)
+	if gameobjects.PlayerInstance.Inventory.IsOpen {
+		gameobjects.PlayerInstance.Inventory.DrawTooltip(invKeyboard)
 	}
 	DrawPlayerHUD()
+	gameobjects.PlayerInstance.DrawHotbar(screenWidth, screenHeight)
+	if currentScene == SceneOutside {
+		if bossFight.Active {
+			bossFight.DrawHealthBar()
+		} else {
+			spawner.DrawWaveHUD()
+		}
+	}
 	DrawMiniMap()
-
-	rl.EndDrawing()
+	drawDoorMenus()
+	drawQuestHUD()
+	conversation.Draw(screenWidth, screenHeight)
 }
 
-// scaleAndDrawFullScreen scales `tex` to exactly 800×450 and draws it at (0,0).
+// scaleAndDrawFullScreen scales `tex` to exactly fill the screen and draws it at (0,0).
 func scaleAndDrawFullScreen(tex rl.Texture2D) {
 	src := rl.NewRectangle(0, 0,
 		float32(tex.Width), float32(tex.Height))
@@ -451,24 +685,28 @@ func DrawWorldBG(tex rl.Texture2D) {
 func DrawPlayerHUD() {
 	player := &gameobjects.PlayerInstance
 
+	// The bars hang from the top-left corner
+	x, y := ui.Place(ui.TopLeft, 0, 0, 20, 20, screenWidth, screenHeight)
+
 	// Health Bar
 	hw := float32(200.0)
 	hh := float32(20.0)
 	hp := float32(player.Health / player.MaxHealth)
-	rl.DrawRectangle(20, 20, int32(hw), int32(hh), rl.DarkGray)
-	rl.DrawRectangle(20, 20, int32(hw*hp), int32(hh), rl.Red)
+	rl.DrawRectangle(x, y, int32(hw), int32(hh), rl.DarkGray)
+	rl.DrawRectangle(x, y, int32(hw*hp), int32(hh), rl.Red)
 	healthText := fmt.Sprintf("Health: %.0f/%.0f", player.Health, player.MaxHealth)
-	rl.DrawText(healthText, 30, 25, 10, rl.White)
+	rl.DrawText(healthText, x+10, y+5, 10, rl.White)
+	rl.DrawText(fmt.Sprintf("Coins: %d", player.Coins), x+210, y+3, 14, rl.Gold)
 
 	// Ammo Bar
-	abY := 45
+	abY := y + 25
 	aw := float32(200.0)
 	ah := float32(15.0)
 	ap := float32(player.Ammo) / float32(player.MaxAmmo)
-	rl.DrawRectangle(20, int32(abY), int32(aw), int32(ah), rl.DarkGray)
-	rl.DrawRectangle(20, int32(abY), int32(aw*ap), int32(ah), rl.Yellow)
+	rl.DrawRectangle(x, abY, int32(aw), int32(ah), rl.DarkGray)
+	rl.DrawRectangle(x, abY, int32(aw*ap), int32(ah), rl.Yellow)
 	ammoText := fmt.Sprintf("Ammo: %d/%d", player.Ammo, player.MaxAmmo)
-	rl.DrawText(ammoText, 30, int32(abY+3), 10, rl.White)
+	rl.DrawText(ammoText, x+10, abY+3, 10, rl.White)
 }
 
 func clamp(v, min, max int) int {
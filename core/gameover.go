@@ -0,0 +1,87 @@
+package core
+
+import (
+	"fmt"
+	"platformer-game/database"
+	"platformer-game/gameobjects"
+	"platformer-game/ui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const gameOverDelay = 1.0 // seconds the body lies still before the game over screen
+
+// runStats is what the game over screen sums up about the run.
+type runStats struct {
+	Time  float32 // seconds played
+	Kills int
+	Coins int // coins picked up
+}
+
+var (
+	stats        runStats
+	deathHold    float32 // seconds since the death animation finished
+	gameOverMenu ui.Menu
+)
+
+// updateDeath plays the player's death with the world frozen around them,
+// then brings up the game over screen.
+func updateDeath(dt float32) {
+	if !gameobjects.PlayerInstance.UpdateDying(dt) {
+		return
+	}
+	deathHold += dt
+	if deathHold >= gameOverDelay {
+		deathHold = 0
+		gameOverMenu.Selected = 0
+		openMenu(menuGameOver)
+	}
+}
+
+func updateGameOverMenu() {
+	slot, saved := database.LastSlot()
+	gameOverMenu.Items = []ui.Item{
+		{Label: "Try Again"},
+		{Label: "Load Last Save", Disabled: !saved},
+		{Label: "Main Menu"},
+	}
+	chosen, _ := gameOverMenu.Update(screenWidth, screenHeight)
+	switch chosen {
+	case 0:
+		// Everything picked up, crafted or bought was saved as it happened
+		startWorld()
+		openMenu(menuNone)
+	case 1:
+		loadSlot(slot)
+	case 2:
+		startWorld()
+		canContinue = true
+		openMenu(menuTitle)
+	}
+}
+
+// statsLines sums up the run for the game over screen.
+func statsLines() []string {
+	secs := int(stats.Time)
+	lines := []string{
+		fmt.Sprintf("Survived %d:%02d", secs/60, secs%60),
+		fmt.Sprintf("Reached wave %d", spawner.Wave),
+		fmt.Sprintf("Zombies killed: %d", stats.Kills),
+		fmt.Sprintf("Coins collected: %d", stats.Coins),
+	}
+	if bossFight.Defeated {
+		lines = append(lines, "Defeated "+bossName)
+	}
+	return lines
+}
+
+func drawGameOver(screenW, screenH int32) {
+	const title = "GAME OVER"
+	w := rl.MeasureText(title, 40)
+	rl.DrawText(title, (screenW-w)/2, 40, 40, rl.Red)
+	for i, l := range statsLines() {
+		lw := rl.MeasureText(l, 18)
+		rl.DrawText(l, (screenW-lw)/2, 95+int32(i)*20, 18, rl.LightGray)
+	}
+	gameOverMenu.Draw(screenW, screenH)
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"platformer-game/gameobjects"
+	"platformer-game/input"
+)
+
+// itemTypes maps item names to their type, for items handed out by scripts,
+// loot and recipes.
+var itemTypes = map[string]gameobjects.ItemType{
+	"Sword":        gameobjects.Weapon,
+	"HealthPack":   gameobjects.HealthPack,
+	"BronzeKey":    gameobjects.KeyType,
+	"AmmoBox":      gameobjects.AmmoType,
+	"Bandage":      gameobjects.HealthPack,
+	"Grenade":      gameobjects.GrenadeType,
+	"Scrap":        gameobjects.Material,
+	"Cloth":        gameobjects.Material,
+	"Powder":       gameobjects.Material,
+	"Can":          gameobjects.Material,
+	"Helmet":       gameobjects.GearType,
+	"Vest":         gameobjects.GearType,
+	"RunningShoes": gameobjects.GearType,
+	"Bandolier":    gameobjects.GearType,
+}
+
+// newItem builds the inventory item called name; gear starts undamaged.
+func newItem(name string) gameobjects.Item {
+	return gameobjects.Item{
+		Type:       itemTypes[name],
+		Name:       name,
+		Image:      itemTextures[name],
+		Durability: gearDefs[name].MaxDurability,
+	}
+}
+
+// itemValues is what each item is worth to a merchant before their sell rate;
+// items missing here can't be sold.
+var itemValues = map[string]int{
+	"Sword":        60,
+	"HealthPack":   30,
+	"AmmoBox":      15,
+	"Bandage":      12,
+	"Grenade":      40,
+	"Scrap":        3,
+	"Cloth":        3,
+	"Powder":       6,
+	"Can":          2,
+	"Helmet":       45,
+	"Vest":         80,
+	"RunningShoes": 50,
+	"Bandolier":    50,
+}
+
+// itemDescriptions is the flavour text shown in item tooltips.
+var itemDescriptions = map[string]string{
+	"Sword":        "Heavy, sharp, and quiet.",
+	"HealthPack":   "Restores 25 health.",
+	"BronzeKey":    "Opens the house down the street.",
+	"AmmoBox":      "Refills your clip.",
+	"Bandage":      "Restores 25 health. Two make a health pack.",
+	"Grenade":      "Throw it and run. 5s between throws.",
+	"Scrap":        "Bits of metal. Used in crafting.",
+	"Cloth":        "A torn rag. Used in crafting.",
+	"Powder":       "Gunpowder. Used in crafting.",
+	"Can":          "An empty tin can. Used in crafting.",
+	"Helmet":       "Dented, but it still works.",
+	"Vest":         "Padded vest with a couple of plates.",
+	"RunningShoes": "For when fighting isn't an option.",
+	"Bandolier":    "Keeps spare rounds where your hands can find them.",
+}
+
+func initItems() {
+	gameobjects.ItemDescriptions = itemDescriptions
+}
+
+// invKeyboard is true while the inventory is being driven by keys or a
+// gamepad rather than the mouse, so the tooltip follows the selection.
+var invKeyboard bool
+
+// updateInventoryKeys moves the inventory selection with the arrow keys or
+// d-pad and uses the selected item with Enter, Space or the gamepad's A
+// button. The crafting panel and open containers have the keys to themselves.
+func updateInventoryKeys() {
+	inv := &gameobjects.PlayerInstance.Inventory
+	if !inv.IsOpen || bench.IsOpen || openContainer != nil || openShop != nil {
+		return
+	}
+	if inv.UpdateSelection() {
+		invKeyboard = true
+	}
+	if input.MouseMoved() {
+		invKeyboard = false
+	}
+	if input.Pressed(input.Confirm) {
+		gameobjects.PlayerInstance.EquipItem(inv.SelectedSlot)
+	}
+}
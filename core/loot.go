@@ -0,0 +1,87 @@
+package core
+
+import (
+	"platformer-game/gameobjects"
+	"platformer-game/loot"
+	"platformer-game/rng"
+)
+
+// lootTables is what each archetype can drop when it dies.
+var lootTables = map[gameobjects.ZombieType]loot.Table{
+	gameobjects.ZombieWalker: {
+		Rolls:   1,
+		Nothing: 70,
+		Entries: []loot.Entry{
+			{Item: "AmmoBox", Weight: 20, Rarity: loot.Common},
+			{Item: "Scrap", Weight: 15, Rarity: loot.Common},
+			{Item: "Cloth", Weight: 10, Rarity: loot.Common},
+			{Item: "HealthPack", Weight: 10, Rarity: loot.Uncommon},
+		},
+	},
+	gameobjects.ZombieRunner: {
+		Rolls:   1,
+		Nothing: 60,
+		Entries: []loot.Entry{
+			{Item: "AmmoBox", Weight: 30, Rarity: loot.Common},
+			{Item: "Can", Weight: 12, Rarity: loot.Common},
+			{Item: "Powder", Weight: 8, Rarity: loot.Uncommon},
+			{Item: "HealthPack", Weight: 8, Rarity: loot.Uncommon},
+			{Item: "BronzeKey", Weight: 2, Rarity: loot.Rare},
+			{Item: "Bandolier", Weight: 2, Rarity: loot.Rare},
+		},
+	},
+	gameobjects.ZombieBrute: {
+		Rolls:   2,
+		Nothing: 30,
+		Entries: []loot.Entry{
+			{Item: "AmmoBox", Weight: 30, Rarity: loot.Common},
+			{Item: "Scrap", Weight: 20, Rarity: loot.Common},
+			{Item: "Powder", Weight: 15, Rarity: loot.Uncommon},
+			{Item: "HealthPack", Weight: 25, Rarity: loot.Uncommon},
+			{Item: "Sword", Weight: 5, Rarity: loot.Rare},
+			{Item: "Vest", Weight: 4, Rarity: loot.Rare},
+		},
+		Guaranteed: []loot.Drop{{Item: "AmmoBox", Rarity: loot.Common}},
+	},
+	gameobjects.ZombieBoss: {
+		Rolls: 3,
+		Entries: []loot.Entry{
+			{Item: "AmmoBox", Weight: 50, Rarity: loot.Common},
+			{Item: "HealthPack", Weight: 50, Rarity: loot.Uncommon},
+		},
+		Guaranteed: []loot.Drop{
+			{Item: "Sword", Rarity: loot.Legendary},
+			{Item: "Vest", Rarity: loot.Rare},
+			{Item: "HealthPack", Rarity: loot.Uncommon},
+		},
+	},
+}
+
+// coinDrops is the range of coins each archetype drops.
+var coinDrops = map[gameobjects.ZombieType][2]int{
+	gameobjects.ZombieWalker: {1, 4},
+	gameobjects.ZombieRunner: {2, 6},
+	gameobjects.ZombieBrute:  {8, 15},
+	gameobjects.ZombieBoss:   {60, 100},
+}
+
+// dropLoot rolls z's loot table and scatters the drops, and a few coins, on
+// the floor the corpse lies on, ledge or ground.
+func dropLoot(z *gameobjects.Zombie) {
+	drops := lootTables[z.Type].Roll(rng.Get(rng.Loot))
+	x := z.Position.X - float32(len(drops)-1)*20
+	floor := z.Feet().Y
+	for i, d := range drops {
+		w := gameobjects.NewDroppedItem(x+float32(i)*40, 0, itemTypes[d.Item], d.Item, itemTextures[d.Item])
+		w.SetOnFloor(floor)
+		w.Glow = d.Rarity.Color()
+		droppedItems = append(droppedItems, w)
+	}
+
+	if r, ok := coinDrops[z.Type]; ok {
+		c := gameobjects.NewDroppedItem(x+float32(len(drops))*40, 0, gameobjects.Other, coinItem, itemTextures[coinItem])
+		c.SetOnFloor(floor)
+		c.Amount = r[0] + rng.Get(rng.Loot).Intn(r[1]-r[0]+1)
+		droppedItems = append(droppedItems, c)
+	}
+}
@@ -0,0 +1,367 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"platformer-game/database"
+	"platformer-game/gameobjects"
+	"platformer-game/input"
+	"platformer-game/ui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// menuScreen is the menu showing over (or instead of) the game.
+type menuScreen int
+
+const (
+	menuNone     menuScreen = iota // playing
+	menuTitle                      // New Game / Continue / Load / Settings / Quit
+	menuPause                      // ESC during play; the world is frozen
+	menuSettings                   // volume and window
+	menuControls                   // what every action is bound to
+	menuSlots                      // the save slots, for saving or loading
+	menuGameOver                   // the player died: try again, load or quit to the title
+)
+
+var (
+	menu        = menuTitle
+	menuBack    menuScreen     // where the settings and the slot list go back to
+	savingSlot  bool           // the slot list saves the game rather than loading one
+	rebinding   = notRebinding // action waiting for a new key on the controls screen
+	menuNotice  string         // one-line message shown under the menu, e.g. "Saved to slot 1"
+	canContinue bool           // there's a game to go back to
+	quit        bool
+
+	titleMenu    = ui.Menu{Title: "Zombie Platformer"}
+	pauseMenu    = ui.Menu{Title: "Paused"}
+	settingsMenu = ui.Menu{Title: "Settings"}
+	controlsMenu = ui.Menu{Title: "Controls"}
+	slotMenu     ui.Menu
+)
+
+const notRebinding input.Action = -1
+
+// ShouldQuit reports whether the player chose Quit from a menu.
+func ShouldQuit() bool {
+	return quit
+}
+
+// inGame reports whether the world shows behind the current menu; it doesn't
+// on the title screen or the menus opened from it.
+func inGame() bool {
+	switch menu {
+	case menuNone, menuPause, menuGameOver:
+		return true
+	case menuSettings, menuControls, menuSlots:
+		return menuBack == menuPause
+	}
+	return false
+}
+
+func openMenu(m menuScreen) {
+	menu = m
+	menuNotice = ""
+	rebinding = notRebinding
+}
+
+// updateMenus runs the menu that's up and returns true while there is one,
+// so the world stays frozen. During play the gamepad's Start pauses, and so
+// does ESC if no panel is open; with one open, ESC is the Back action and the
+// tick closes the panel, so a recording sees it.
+func updateMenus() bool {
+	switch menu {
+	case menuNone:
+		if rl.IsGamepadButtonPressed(0, rl.GamepadButtonMiddleRight) ||
+			rl.IsKeyPressed(rl.KeyEscape) && !panelsOpen() {
+			openMenu(menuPause)
+		}
+		return menu != menuNone
+	case menuTitle:
+		updateTitleMenu()
+	case menuPause:
+		updatePauseMenu()
+	case menuSettings:
+		updateSettingsMenu()
+	case menuControls:
+		updateControlsMenu()
+	case menuSlots:
+		updateSlotMenu()
+	case menuGameOver:
+		updateGameOverMenu()
+	}
+	return true
+}
+
+// panelsOpen reports whether the inventory, a container, a shop, the
+// crafting panel or the quest log is open.
+func panelsOpen() bool {
+	inv := &gameobjects.PlayerInstance.Inventory
+	return inv.IsOpen || quests.IsOpen || openContainer != nil || openShop != nil || bench.IsOpen
+}
+
+// closePanels closes the inventory, container, shop, crafting panel and
+// quest log.
+func closePanels() {
+	inv := &gameobjects.PlayerInstance.Inventory
+	closeContainer()
+	closeShop()
+	if bench.IsOpen {
+		bench.Toggle()
+	}
+	inv.IsOpen = false
+	quests.IsOpen = false
+}
+
+func updateTitleMenu() {
+	titleMenu.Items = []ui.Item{
+		{Label: "New Game"},
+		{Label: "Continue", Disabled: !canContinue},
+		{Label: "Load Game"},
+		{Label: "Settings"},
+		{Label: "Quit"},
+	}
+	chosen, _ := titleMenu.Update(screenWidth, screenHeight)
+	switch chosen {
+	case 0:
+		if err := database.Reset(); err != nil {
+			log.Println("Failed to clear the old game:", err)
+		}
+		startWorld()
+		openMenu(menuNone)
+	case 1:
+		openMenu(menuNone)
+	case 2:
+		openSlots(false, menuTitle)
+	case 3:
+		menuBack = menuTitle
+		openMenu(menuSettings)
+	case 4:
+		quit = true
+	}
+}
+
+func updatePauseMenu() {
+	pauseMenu.Items = []ui.Item{
+		{Label: "Resume"},
+		{Label: "Save Game"},
+		{Label: "Settings"},
+		{Label: "Main Menu"},
+		{Label: "Quit"},
+	}
+	chosen, _ := pauseMenu.Update(screenWidth, screenHeight)
+	if ui.Back() {
+		chosen = 0
+	}
+	switch chosen {
+	case 0:
+		openMenu(menuNone)
+	case 1:
+		openSlots(true, menuPause)
+	case 2:
+		menuBack = menuPause
+		openMenu(menuSettings)
+	case 3:
+		canContinue = true
+		openMenu(menuTitle)
+	case 4:
+		quit = true
+	}
+}
+
+// ─── Settings ───
+
+func updateSettingsMenu() {
+	onOff := map[bool]string{true: "On", false: "Off"}
+	scalings := map[bool]string{true: "Whole steps", false: "Fit"}
+	res := resolutions[settings.Resolution]
+	settingsMenu.Items = []ui.Item{
+		{Label: "Volume", Value: fmt.Sprintf("%d%%", int(settings.Volume*100+0.5))},
+		{Label: "Resolution", Value: fmt.Sprintf("%dx%d", res[0], res[1]), Disabled: settings.Fullscreen},
+		{Label: "Fullscreen", Value: onOff[settings.Fullscreen]},
+		{Label: "Scaling", Value: scalings[settings.Integer]},
+		{Label: "Controls"},
+		{Label: "Back"},
+	}
+
+	chosen, step := settingsMenu.Update(screenWidth, screenHeight)
+	if ui.Back() || chosen == 5 {
+		saveSettings()
+		openMenu(menuBack)
+		return
+	}
+	if chosen == 4 {
+		controlsMenu.Selected = 0
+		openMenu(menuControls)
+		return
+	}
+
+	// Clicking or pressing Enter on a setting steps it forward
+	sel := settingsMenu.Selected
+	if chosen >= 0 {
+		sel, step = chosen, 1
+	}
+	switch {
+	case step == 0:
+	case sel == 0:
+		v := settings.Volume + float32(step)*0.1
+		if chosen == 0 && v > 1.05 {
+			v = 0 // clicking past full volume wraps to silent
+		}
+		settings.Volume = rl.Clamp(v, 0, 1)
+		applySettings()
+	case sel == 1:
+		settings.Resolution = (settings.Resolution + step + len(resolutions)) % len(resolutions)
+		applySettings()
+	case sel == 2:
+		settings.Fullscreen = !settings.Fullscreen
+		applySettings()
+	case sel == 3:
+		settings.Integer = !settings.Integer
+		applySettings()
+	}
+}
+
+// updateControlsMenu lists what every action is bound to. Choosing one waits
+// for the new key or gamepad button.
+func updateControlsMenu() {
+	if rebinding != notRebinding {
+		if k := rl.GetKeyPressed(); k != 0 {
+			if k != rl.KeyEscape {
+				settings.Controls.Rebind(rebinding, k, false)
+				saveSettings()
+			}
+			rebinding = notRebinding
+		} else if btn := input.PressedButton(); btn >= 0 {
+			settings.Controls.Rebind(rebinding, btn, true)
+			saveSettings()
+			rebinding = notRebinding
+		}
+		return
+	}
+
+	var items []ui.Item
+	for _, a := range input.Rebindable {
+		items = append(items, ui.Item{Label: a.String(), Value: settings.Controls[a].String()})
+	}
+	items = append(items, ui.Item{Label: "Reset to defaults"}, ui.Item{Label: "Back"})
+	controlsMenu.Items = items
+
+	chosen, _ := controlsMenu.Update(screenWidth, screenHeight)
+	n := len(input.Rebindable)
+	switch {
+	case ui.Back() || chosen == n+1:
+		openMenu(menuSettings)
+	case chosen == n:
+		settings.Controls = input.DefaultBindings()
+		applySettings()
+		saveSettings()
+	case chosen >= 0:
+		rebinding = input.Rebindable[chosen]
+	}
+}
+
+// ─── Save slots ───
+
+// openSlots shows the save slots, for saving the game in progress or for
+// loading one, and goes back to from when done.
+func openSlots(saving bool, from menuScreen) {
+	savingSlot = saving
+	menuBack = from
+	slotMenu.Selected = 0
+	slotMenu.Title = "Load Game"
+	if saving {
+		slotMenu.Title = "Save Game"
+	}
+	openMenu(menuSlots)
+}
+
+func updateSlotMenu() {
+	var items []ui.Item
+	for i := 0; i < database.NumSlots; i++ {
+		it := ui.Item{Label: fmt.Sprintf("Slot %d", i+1), Value: "Empty"}
+		if t, ok := database.SlotSaved(i); ok {
+			it.Value = t.Format("Jan 2 15:04")
+		} else {
+			it.Disabled = !savingSlot
+		}
+		items = append(items, it)
+	}
+	items = append(items, ui.Item{Label: "Back"})
+	slotMenu.Items = items
+
+	chosen, _ := slotMenu.Update(screenWidth, screenHeight)
+	if ui.Back() || chosen == database.NumSlots {
+		openMenu(menuBack)
+		return
+	}
+	if chosen < 0 {
+		return
+	}
+
+	if savingSlot {
+		if err := database.SaveSlot(chosen); err != nil {
+			log.Println("Failed to save:", err)
+			menuNotice = "Couldn't save to that slot"
+			return
+		}
+		log.Printf("Saved to slot %d\n", chosen+1)
+		openMenu(menuPause)
+		menuNotice = fmt.Sprintf("Saved to slot %d", chosen+1)
+		return
+	}
+	loadSlot(chosen)
+}
+
+// loadSlot makes a save slot the game in progress and starts playing it.
+func loadSlot(slot int) {
+	if err := database.LoadSlot(slot); err != nil {
+		log.Println("Failed to load:", err)
+		menuNotice = "Couldn't load that slot"
+		return
+	}
+	log.Printf("Loaded slot %d\n", slot+1)
+	startWorld()
+	openMenu(menuNone)
+}
+
+// ─── Drawing ───
+
+// drawMenus draws the menu that's up, if any, over everything else.
+func drawMenus() {
+	if menu == menuNone {
+		return
+	}
+	sw, sh := int32(screenWidth), int32(screenHeight)
+	if !inGame() {
+		scaleAndDrawFullScreen(outsideBG)
+	}
+	ui.Dim(sw, sh)
+
+	hint := "Arrow keys or mouse to choose, Enter to select"
+	switch menu {
+	case menuTitle:
+		titleMenu.Draw(sw, sh)
+	case menuPause:
+		pauseMenu.Draw(sw, sh)
+		hint = "Esc to resume"
+	case menuSettings:
+		settingsMenu.Draw(sw, sh)
+		hint = "Left/Right to change, Esc to go back"
+	case menuControls:
+		controlsMenu.Draw(sw, sh)
+		hint = "Enter to rebind, Esc to go back"
+		if rebinding != notRebinding {
+			hint = fmt.Sprintf("Press a key or gamepad button for %s (Esc to cancel)", rebinding)
+		}
+	case menuSlots:
+		slotMenu.Draw(sw, sh)
+		hint = "Esc to go back"
+	case menuGameOver:
+		drawGameOver(sw, sh)
+	}
+	if menuNotice != "" {
+		hint = menuNotice
+	}
+	ui.Hint(hint, sw, sh)
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"platformer-game/quest"
+	"platformer-game/ui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const questBannerTime = 3 // seconds the "quest complete" banner stays up
+
+var (
+	quests           *quest.Log
+	questBanner      string
+	questBannerTimer float32
+)
+
+// defaultQuests are the quests every new game starts with.
+func defaultQuests() []*quest.Quest {
+	return []*quest.Quest{
+		{
+			ID:          "into_the_house",
+			Title:       "A Roof Over Your Head",
+			Description: "The house down the street is locked. Find a way in.",
+			Objectives: []*quest.Objective{
+				{Kind: quest.Collect, Text: "Find the bronze key", Target: "BronzeKey"},
+				{Kind: quest.OpenDoor, Text: "Open the house door", Target: "HouseFront"},
+			},
+		},
+		{
+			ID:          "clear_the_street",
+			Title:       "Clear the Street",
+			Description: "Thin out the horde so Sam can breathe.",
+			Objectives: []*quest.Objective{
+				{Kind: quest.Kill, Text: "Kill zombies", Count: 20},
+				{Kind: quest.Kill, Text: "Kill brutes", Target: "Brute", Count: 3},
+			},
+		},
+		{
+			ID:          "the_butcher",
+			Title:       "The Butcher",
+			Description: "Something big is waiting at the east end of the street.",
+			Objectives: []*quest.Objective{
+				{Kind: quest.Reach, Text: "Enter the arena", Area: rl.Rectangle{X: arenaLeft, Y: 0, Width: arenaRight - arenaLeft, Height: worldHeight}},
+				{Kind: quest.Kill, Text: "Kill " + bossName, Target: "Boss"},
+			},
+		},
+	}
+}
+
+func initQuests() {
+	quests = quest.NewLog(defaultQuests())
+	quests.LoadFromDB()
+	quests.OnComplete = func(q *quest.Quest) {
+		questBanner = "Quest complete: " + q.Title
+		questBannerTimer = questBannerTime
+	}
+}
+
+// drawQuestHUD draws the objective tracker, the completion banner and, when
+// open, the quest log.
+func drawQuestHUD() {
+	quests.DrawTracker(ui.Place(ui.TopLeft, 0, 0, 20, 70, screenWidth, screenHeight))
+	if questBannerTimer > 0 {
+		questBannerTimer -= rl.GetFrameTime()
+		w := rl.MeasureText(questBanner, 20)
+		x, y := ui.Place(ui.TopCenter, w, 20, 0, 60, screenWidth, screenHeight)
+		rl.DrawText(questBanner, x, y, 20, rl.Gold)
+	}
+	if quests.IsOpen {
+		quests.DrawLog(screenWidth, screenHeight)
+	}
+}
+
+// onItemPickedUp is the hook every pickup path calls.
+func onItemPickedUp(name string) {
+	quests.ItemCollected(name)
+	startItemDialogue(name)
+}
@@ -0,0 +1,141 @@
+package core
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"platformer-game/clock"
+	"platformer-game/config"
+	"platformer-game/database"
+	"platformer-game/gameobjects"
+	"platformer-game/input"
+	"platformer-game/replay"
+	"platformer-game/rng"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// replayDB is the scratch database recorded and replayed runs play in, next
+// to the real one, so they always start from a fresh game and never touch
+// the player's.
+const replayDB = "replay.db"
+
+var (
+	newSeed  int64            // seed the config asks for, for new games
+	recorder *replay.Recorder // non-nil while the run is being recorded
+	playback *replay.Replay   // non-nil while a replay is playing
+	playTick int              // next tick of playback
+	headless bool
+	scratch  bool // the run is recorded or replayed, in replayDB
+)
+
+// initReplay opens the recording or the replay the config asks for. Either
+// way the game runs in the scratch database, straight into the world without
+// the title screen.
+func initReplay(cfg config.Config) {
+	headless = cfg.Headless && cfg.Replay != ""
+	newSeed = cfg.Seed
+	if cfg.Record == "" && cfg.Replay == "" {
+		return
+	}
+	scratch = true
+	if cfg.Replay != "" {
+		r, err := replay.Load(cfg.Replay)
+		if err != nil {
+			log.Println("Failed to load replay:", err)
+			quit = true
+			return
+		}
+		playback = r
+		input.SetSource(&input.Script{Frames: r.Input})
+		log.Printf("Playing back %s: %d ticks, seed %d\n", cfg.Replay, r.Ticks(), r.Seed)
+	}
+	path := filepath.Join(filepath.Dir(database.Path), replayDB)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		log.Println("Failed to clear the replay database:", err)
+	}
+	database.Path = path
+	menu = menuNone
+}
+
+// startRecording begins writing the run to path; call it once the world is
+// built, so the file carries the seed it was built from.
+func startRecording(path string) {
+	rec, err := replay.Create(path, rng.Seed())
+	if err != nil {
+		log.Println("Failed to start recording:", err)
+		return
+	}
+	recorder = rec
+	log.Printf("Recording to %s (seed %d)\n", path, rng.Seed())
+}
+
+func stopRecording() {
+	if recorder == nil {
+		return
+	}
+	if err := recorder.Close(); err != nil {
+		log.Println("Failed to finish recording:", err)
+	}
+	recorder = nil
+}
+
+// seedRun restarts the random streams for a run: from the replay's seed when
+// playing one back, and otherwise from the seed saved with the game, the
+// -seed option or the clock. A saved game is a run started again (Continue,
+// Load or Try Again), so it counts an attempt and plays from that attempt's
+// seed: the same world, but fresh drops and waves. The seed and attempt are
+// saved with the game, so save slots keep them too.
+func seedRun() {
+	base, attempt, saved := database.LoadSeed()
+	switch {
+	case playback != nil:
+		base, attempt = playback.Seed, 0
+	case saved:
+		attempt++
+	case newSeed != 0:
+		base, attempt = newSeed, 0
+	default:
+		base, attempt = rng.NewSeed(), 0
+	}
+	seed := rng.Derive(base, attempt)
+	rng.Reseed(seed)
+	database.SaveSeed(base, attempt)
+	log.Printf("Run seed: %d (game seed %d, attempt %d)\n", seed, base, attempt)
+}
+
+// beginTick starts one tick of the world: it reads this tick's actions (from
+// the replay, when playing one), moves the game clock on and records both.
+func beginTick() {
+	dt := rl.GetFrameTime()
+	if playback != nil && playTick < playback.Ticks() {
+		dt = playback.DT[playTick]
+		playTick++
+	}
+	input.Update()
+	clock.Tick(dt)
+	if recorder != nil {
+		if err := recorder.Tick(dt, input.Current()); err != nil {
+			log.Println("Failed to record tick:", err)
+			stopRecording()
+		}
+	}
+}
+
+// playbackOver reports whether the replay has run out of ticks, or (headless,
+// where nobody can close it) is stuck behind a menu. It logs where the run
+// ended up, for comparing against other runs of the same replay.
+func playbackOver() bool {
+	if playback == nil || playTick < playback.Ticks() && !(headless && menu != menuNone) {
+		return false
+	}
+	p := &gameobjects.PlayerInstance
+	log.Printf("Replay finished at tick %d/%d: player at (%.1f, %.1f), health %.0f, kills %d, wave %d\n",
+		playTick, playback.Ticks(), p.Position.X, p.Position.Y, p.Health, stats.Kills, spawner.Wave)
+	return true
+}
+
+// Headless reports whether the game runs without drawing.
+func Headless() bool {
+	return headless
+}
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"platformer-game/config"
+	"platformer-game/gameobjects"
+	"platformer-game/input"
+	"platformer-game/rendering"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const settingsFile = "settings.json"
+
+// Settings are the player's preferences. They live in their own file rather
+// than the database, so starting a new game or loading a save keeps them.
+type Settings struct {
+	Volume     float32        `json:"volume"`     // master volume, 0-1
+	Resolution int            `json:"resolution"` // index into resolutions
+	Fullscreen bool           `json:"fullscreen"`
+	Integer    bool           `json:"integer_scaling"` // scale the screen by whole steps only
+	Controls   input.Bindings `json:"controls"`
+	Hotbar     []int          `json:"hotbar,omitempty"` // inventory slot for each number key
+}
+
+// resolutions are the window sizes the settings screen offers.
+var resolutions = [][2]int{
+	{800, 450},
+	{1280, 720},
+	{1600, 900},
+	{1920, 1080},
+}
+
+func defaultSettings() Settings {
+	return Settings{
+		Volume:   1,
+		Controls: input.DefaultBindings(),
+	}
+}
+
+var settings = defaultSettings()
+
+// loadSettings reads the settings file; anything missing keeps its default.
+func loadSettings() {
+	data, err := os.ReadFile(settingsFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Println("Failed to read settings:", err)
+		}
+		return
+	}
+	s := defaultSettings()
+	if err := json.Unmarshal(data, &s); err != nil {
+		log.Println("Failed to parse settings:", err)
+		return
+	}
+	// Unmarshal adds to the default bindings, so actions missing from the
+	// file keep their default controls.
+	if s.Controls == nil {
+		s.Controls = input.DefaultBindings()
+	}
+	if s.Resolution < 0 || s.Resolution >= len(resolutions) {
+		s.Resolution = 0
+	}
+	settings = s
+}
+
+func saveSettings() {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		log.Println("Failed to encode settings:", err)
+		return
+	}
+	if err := os.WriteFile(settingsFile, data, 0o644); err != nil {
+		log.Println("Failed to save settings:", err)
+	}
+}
+
+// restoreHotbar gives the player the number-key bindings from the settings
+// and saves them back there whenever a key is rebound. Recorded and replayed
+// runs keep the default bindings, so a replay plays out the same whatever the
+// player has bound since.
+func restoreHotbar() {
+	h := &gameobjects.PlayerInstance.Hotbar
+	if scratch {
+		return
+	}
+	if len(settings.Hotbar) == len(h.Slots) {
+		copy(h.Slots[:], settings.Hotbar)
+	}
+	h.OnBind = func() {
+		settings.Hotbar = slices.Clone(h.Slots[:])
+		saveSettings()
+	}
+}
+
+// canvas is the virtual screen the game draws on, scaled to fit the window.
+var canvas *rendering.Canvas
+
+// openWindow opens the game window as the config asks, at the game's own
+// size unless it gives one. The window can be resized freely.
+func openWindow(cfg config.Config) {
+	flags := uint32(rl.FlagWindowResizable)
+	if cfg.VSync {
+		flags |= rl.FlagVsyncHint
+	}
+	if cfg.Headless && cfg.Replay != "" {
+		flags |= rl.FlagWindowHidden // raylib still needs a window to load textures into
+	}
+	rl.SetConfigFlags(flags)
+	w, h := int32(screenWidth), int32(screenHeight)
+	if cfg.Width > 0 {
+		w, h = int32(cfg.Width), int32(cfg.Height)
+	}
+	rl.InitWindow(w, h, "Platformer Game")
+	rl.SetExitKey(0) // ESC opens the pause menu; quitting is done from the menus
+	if cfg.TargetFPS > 0 {
+		rl.SetTargetFPS(int32(cfg.TargetFPS))
+	}
+	canvas = rendering.NewCanvas()
+}
+
+// applyWindowConfig gives the window size and fullscreen mode from the
+// config the last word at startup; the settings screen can still change them.
+func applyWindowConfig(cfg config.Config) {
+	if cfg.Fullscreen && !rl.IsWindowFullscreen() {
+		rl.ToggleFullscreen()
+	}
+	if cfg.Width > 0 && !rl.IsWindowFullscreen() {
+		rl.SetWindowSize(cfg.Width, cfg.Height)
+	}
+}
+
+// applySettings pushes the volume, window size and fullscreen mode to raylib,
+// and the controls to the input package.
+func applySettings() {
+	input.Controls = settings.Controls
+	canvas.Integer = settings.Integer
+	rl.SetMasterVolume(settings.Volume)
+	if settings.Fullscreen != rl.IsWindowFullscreen() {
+		rl.ToggleFullscreen()
+	}
+	if !settings.Fullscreen {
+		r := resolutions[settings.Resolution]
+		rl.SetWindowSize(r[0], r[1])
+	}
+}
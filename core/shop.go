@@ -0,0 +1,216 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"platformer-game/config"
+	"platformer-game/gameobjects"
+	"platformer-game/input"
+	"platformer-game/level"
+	"platformer-game/shop"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	shopsDir = "shops" // under the asset root
+	coinItem = "Coins" // name of the coin pickup zombies drop
+)
+
+var (
+	shops        map[string]*shop.Shop
+	merchants    map[SceneID][]*gameobjects.Merchant
+	openShop     *shop.Shop            // the shop being traded with, or nil
+	shopMerchant *gameobjects.Merchant // who runs openShop
+	shopGrid     gameobjects.Inventory // openShop's shelves, one slot per stock entry
+)
+
+func initShops() {
+	shops = shop.LoadShops(config.Asset(shopsDir))
+	for _, s := range shops {
+		s.LoadFromDB()
+	}
+	merchants = map[SceneID][]*gameobjects.Merchant{
+		SceneOutside: buildMerchants(outsideLevel),
+		SceneInside:  buildMerchants(insideLevel),
+	}
+	gameobjects.PlayerInstance.LoadWalletFromDB()
+}
+
+// buildMerchants creates the shopkeepers described in a level.
+func buildMerchants(l *level.Level) []*gameobjects.Merchant {
+	var out []*gameobjects.Merchant
+	for _, def := range l.Merchants {
+		if _, ok := shops[def.Shop]; !ok {
+			log.Printf("Merchant %s has no shop file %q, skipping\n", def.ID, def.Shop)
+			continue
+		}
+		out = append(out, gameobjects.NewMerchant(def.ID, def.Name, def.Shop, def.X, l.Ground))
+	}
+	return out
+}
+
+// updateShops restocks every shop, open or not.
+func updateShops(dt float32) {
+	for _, s := range shops {
+		if s.Update(dt) && s == openShop {
+			refreshShopGrid()
+		}
+	}
+}
+
+// interactWithMerchant handles E at a merchant: open their shop, or close it
+// if it's already open. Returns true if the player was at a merchant.
+func interactWithMerchant() bool {
+	p := &gameobjects.PlayerInstance
+	for _, m := range merchants[currentScene] {
+		if !m.PlayerNear(p.Position, p.Width, p.Height) {
+			continue
+		}
+		if m == shopMerchant {
+			closeShop()
+			return true
+		}
+		closeContainer()
+		if bench.IsOpen {
+			bench.Toggle()
+		}
+		openShop = shops[m.Shop]
+		shopMerchant = m
+		refreshShopGrid()
+		p.Inventory.IsOpen = true
+		p.Inventory.TooltipExtra = sellTooltip
+		log.Printf("Trading with %s\n", m.Name)
+		return true
+	}
+	return false
+}
+
+func closeShop() {
+	if openShop == nil {
+		return
+	}
+	openShop = nil
+	shopMerchant = nil
+	gameobjects.PlayerInstance.Inventory.TooltipExtra = nil
+}
+
+// refreshShopGrid lays out the open shop's shelves as an inventory grid.
+func refreshShopGrid() {
+	shopGrid = gameobjects.NewInventory(len(openShop.Stock))
+	shopGrid.SelectedSlot = -1
+	shopGrid.TooltipExtra = buyTooltip
+	for i, e := range openShop.Stock {
+		if e.Quantity > 0 {
+			it := newItem(e.Item)
+			it.Count = e.Quantity
+			shopGrid.Slots[i] = it
+		}
+	}
+}
+
+// updateOpenShop handles trading while a shop is open: click the shop's grid
+// to buy one, click your own grid to sell one. The shop closes when the
+// player walks away, closes the inventory or leaves the scene.
+func updateOpenShop() {
+	if openShop == nil {
+		return
+	}
+	p := &gameobjects.PlayerInstance
+	if !p.Inventory.IsOpen || fading || !shopMerchant.PlayerNear(p.Position, p.Width, p.Height) {
+		closeShop()
+		return
+	}
+	if !input.Pressed(input.Click) {
+		return
+	}
+	m := input.Mouse()
+	shopGrid.Beside(&p.Inventory)
+	if i := shopGrid.SlotAt(m.X, m.Y); i >= 0 {
+		buy(openShop.Stock[i])
+	} else if i := p.Inventory.SlotAt(m.X, m.Y); i >= 0 && p.Inventory.Slots[i].Type != gameobjects.Other {
+		sell(i)
+	}
+}
+
+func buy(e *shop.Entry) {
+	p := &gameobjects.PlayerInstance
+	if e.Quantity <= 0 {
+		return
+	}
+	price := openShop.BuyPrice(e)
+	if price <= 0 {
+		log.Printf("%s has no price for the %s\n", openShop.Name, e.Item)
+		return
+	}
+	if p.Coins < price {
+		log.Printf("Not enough coins for the %s (%d)\n", e.Item, price)
+		return
+	}
+	if !p.Inventory.AddItem(newItem(e.Item)) {
+		log.Println("Inventory full!")
+		return
+	}
+	p.SpendCoins(price)
+	p.Inventory.SaveToDB()
+	openShop.Take(e)
+	refreshShopGrid()
+	log.Printf("Bought %s for %d coins\n", e.Item, price)
+	onItemPickedUp(e.Item)
+}
+
+func sell(i int) {
+	p := &gameobjects.PlayerInstance
+	it := p.Inventory.Slots[i]
+	price := sellPrice(it)
+	if price <= 0 {
+		log.Printf("%s won't buy the %s\n", openShop.Name, it.Name)
+		return
+	}
+	p.Inventory.RemoveAt(i)
+	openShop.Put(it.Name)
+	p.AddCoins(price)
+	refreshShopGrid()
+	log.Printf("Sold %s for %d coins\n", it.Name, price)
+}
+
+// sellPrice is what the open shop pays for one it; worn gear sells for less.
+func sellPrice(it gameobjects.Item) int {
+	condition := float32(1)
+	if g, ok := gearDefs[it.Name]; ok && g.MaxDurability > 0 {
+		condition = float32(it.Durability) / float32(g.MaxDurability)
+	}
+	return openShop.SellPrice(it.Name, itemValues[it.Name], condition)
+}
+
+func buyTooltip(it gameobjects.Item, i int) []string {
+	e := openShop.Stock[i]
+	return []string{fmt.Sprintf("Buy: %d coins (%d left)", openShop.BuyPrice(e), e.Quantity)}
+}
+
+func sellTooltip(it gameobjects.Item, i int) []string {
+	if price := sellPrice(it); price > 0 {
+		return []string{fmt.Sprintf("Sell: %d coins", price)}
+	}
+	return []string{openShop.Name + " won't buy this"}
+}
+
+// drawMerchants draws the shopkeepers of the current scene (world space).
+func drawMerchants() {
+	for _, m := range merchants[currentScene] {
+		m.Draw()
+	}
+}
+
+// drawShop draws the open shop's shelves next to the inventory (screen space).
+func drawShop() {
+	if openShop == nil {
+		return
+	}
+	shopGrid.Beside(&gameobjects.PlayerInstance.Inventory)
+	x, y := int32(shopGrid.Origin.X), int32(shopGrid.Origin.Y)
+	rl.DrawText(openShop.Name, x, y-20, 16, rl.White)
+	rl.DrawText("Click to buy, click your items to sell", x, y+130, 12, rl.LightGray)
+	shopGrid.DrawInventory()
+	shopGrid.DrawTooltip(false)
+}
@@ -0,0 +1,259 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"platformer-game/gameobjects"
+	"platformer-game/rng"
+	"platformer-game/ui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// WaveDef describes one wave of zombies.
+type WaveDef struct {
+	Count        int                            // how many zombies the wave contains
+	Mix          map[gameobjects.ZombieType]int // relative weight of each archetype
+	SpawnPoints  []float32                      // X positions to spawn at; empty = anywhere away from the player
+	SpawnDelay   float32                        // seconds between individual spawns
+	Intermission float32                        // seconds of quiet before the wave starts
+}
+
+// defaultWaves is the hand-made opening of the level. Past the last entry the
+// spawner keeps repeating it with more zombies and a higher difficulty.
+var defaultWaves = []WaveDef{
+	{
+		Count:        5,
+		Mix:          map[gameobjects.ZombieType]int{gameobjects.ZombieWalker: 1},
+		SpawnDelay:   0.5,
+		Intermission: 3,
+	},
+	{
+		Count:        7,
+		Mix:          map[gameobjects.ZombieType]int{gameobjects.ZombieWalker: 3, gameobjects.ZombieRunner: 1},
+		SpawnPoints:  []float32{60, worldWidth - 160},
+		SpawnDelay:   1.0,
+		Intermission: 8,
+	},
+	{
+		Count:        9,
+		Mix:          map[gameobjects.ZombieType]int{gameobjects.ZombieWalker: 3, gameobjects.ZombieRunner: 2, gameobjects.ZombieBrute: 1},
+		SpawnDelay:   1.0,
+		Intermission: 10,
+	},
+}
+
+const (
+	maxAliveZombies    = 12    // never more than this many zombies at once
+	spawnMinPlayerDist = 400.0 // random spawns keep at least this far from the player
+	waveCountGrowth    = 2     // extra zombies per wave past the defined list
+	waveDifficultyStep = 0.15  // +15% health/damage per wave
+)
+
+// SpawnerPhase is where the spawner is in the wave cycle.
+type SpawnerPhase int
+
+const (
+	SpawnerIntermission SpawnerPhase = iota // counting down to the next wave
+	SpawnerSpawning                         // still has zombies to put in the world
+	SpawnerFighting                         // everything spawned, waiting for the wave to die
+)
+
+// Spawner feeds zombies into the world wave by wave.
+type Spawner struct {
+	Waves    []WaveDef
+	MaxAlive int
+	Paused   bool // no new waves (e.g. during the boss fight); minions can still be summoned
+
+	Wave    int // 1-based number of the current (or upcoming) wave
+	Phase   SpawnerPhase
+	timer   float32 // intermission countdown / time until next spawn
+	spawned int     // zombies of the current wave already in the world
+	rnd     *rand.Rand
+}
+
+func NewSpawner(waves []WaveDef, maxAlive int) *Spawner {
+	s := &Spawner{
+		Waves:    waves,
+		MaxAlive: maxAlive,
+		rnd:      rng.Get(rng.Spawning),
+	}
+	s.startIntermission(1)
+	return s
+}
+
+// current returns the definition of the current wave, escalated past the end of Waves.
+func (s *Spawner) current() WaveDef {
+	if s.Wave <= len(s.Waves) {
+		return s.Waves[s.Wave-1]
+	}
+	def := s.Waves[len(s.Waves)-1]
+	def.Count += (s.Wave - len(s.Waves)) * waveCountGrowth
+	return def
+}
+
+// Difficulty is the health/damage multiplier for the current wave.
+func (s *Spawner) Difficulty() float32 {
+	return 1 + waveDifficultyStep*float32(s.Wave-1)
+}
+
+// Remaining is how many zombies of this wave are still to be killed.
+func (s *Spawner) Remaining() int {
+	if s.Phase == SpawnerIntermission {
+		return 0
+	}
+	return s.current().Count - s.spawned + waveZombies()
+}
+
+// waveZombies counts the zombies still in the world that came with a wave,
+// leaving out the boss and its minions.
+func waveZombies() int {
+	n := 0
+	for _, z := range zombies {
+		if z.FromWave {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *Spawner) startIntermission(wave int) {
+	s.Wave = wave
+	s.Phase = SpawnerIntermission
+	s.spawned = 0
+	s.timer = s.current().Intermission
+}
+
+// RestartWave puts the current wave back into its intermission, e.g. after
+// the player comes back outside and the old zombies were cleared.
+func (s *Spawner) RestartWave() {
+	s.startIntermission(s.Wave)
+}
+
+// Update advances timers and spawns zombies into the global zombies slice.
+func (s *Spawner) Update(dt float32, playerX float32) {
+	if s.Paused {
+		return
+	}
+	def := s.current()
+	s.timer -= dt
+
+	switch s.Phase {
+	case SpawnerIntermission:
+		if s.timer <= 0 {
+			log.Printf("Wave %d starting (%d zombies)", s.Wave, def.Count)
+			s.Phase = SpawnerSpawning
+			s.timer = 0
+		}
+
+	case SpawnerSpawning:
+		if s.timer > 0 || len(zombies) >= s.MaxAlive {
+			return
+		}
+		s.spawnOne(def, playerX)
+		s.spawned++
+		s.timer = def.SpawnDelay
+		if s.spawned >= def.Count {
+			s.Phase = SpawnerFighting
+		}
+
+	case SpawnerFighting:
+		if waveZombies() == 0 {
+			log.Printf("Wave %d cleared", s.Wave)
+			s.startIntermission(s.Wave + 1)
+		}
+	}
+}
+
+func (s *Spawner) spawnOne(def WaveDef, playerX float32) {
+	s.spawnAt(s.pickX(def, playerX), s.pickType(def)).FromWave = true
+}
+
+// spawnAt brings a zombie in at x, on top of whatever ledge is there.
+func (s *Spawner) spawnAt(x float32, t gameobjects.ZombieType) *gameobjects.Zombie {
+	z := gameobjects.InitZombie(x, outsideLevel.FloorAt(x)-50, t)
+	z.ApplyDifficulty(s.Difficulty())
+	z.Nav = navGraph
+	horde.Join(&z.Mind)
+	zombies = append(zombies, &z)
+	return &z
+}
+
+// SpawnMinions drops n extra zombies (walkers and runners) either side of
+// nearX. They don't count towards the current wave and ignore MaxAlive,
+// since whoever summoned them already decided how many there should be.
+func (s *Spawner) SpawnMinions(n int, nearX float32) {
+	for i := 0; i < n; i++ {
+		offset := float32(120 + s.rnd.Intn(120))
+		if i%2 == 0 {
+			offset = -offset
+		}
+		t := gameobjects.ZombieWalker
+		if s.rnd.Intn(3) == 0 {
+			t = gameobjects.ZombieRunner
+		}
+		x := nearX + offset
+		if x < 50 {
+			x = 50
+		} else if x > worldWidth-150 {
+			x = worldWidth - 150
+		}
+		s.spawnAt(x, t)
+	}
+}
+
+// pickType rolls an archetype from the wave's weighted mix.
+func (s *Spawner) pickType(def WaveDef) gameobjects.ZombieType {
+	total := 0
+	for _, w := range def.Mix {
+		total += w
+	}
+	if total == 0 {
+		return gameobjects.ZombieWalker
+	}
+	// Walk the types in a fixed order so the same roll always gives the same type
+	roll := s.rnd.Intn(total)
+	for t := gameobjects.ZombieWalker; t <= gameobjects.ZombieBrute; t++ {
+		roll -= def.Mix[t]
+		if roll < 0 {
+			return t
+		}
+	}
+	return gameobjects.ZombieWalker
+}
+
+// pickX chooses a spawn position: one of the wave's spawn points, or a random
+// spot on the ground that isn't right on top of the player.
+func (s *Spawner) pickX(def WaveDef, playerX float32) float32 {
+	if len(def.SpawnPoints) > 0 {
+		return def.SpawnPoints[s.rnd.Intn(len(def.SpawnPoints))]
+	}
+	x := float32(s.rnd.Intn(worldWidth-100) + 50)
+	for tries := 0; tries < 10 && abs32(x-playerX) < spawnMinPlayerDist; tries++ {
+		x = float32(s.rnd.Intn(worldWidth-100) + 50)
+	}
+	return x
+}
+
+// DrawWaveHUD shows the wave number and either the countdown or the zombies left.
+func (s *Spawner) DrawWaveHUD() {
+	var text string
+	if s.Phase == SpawnerIntermission {
+		text = fmt.Sprintf("Wave %d in %.0fs", s.Wave, s.timer+0.5)
+	} else {
+		text = fmt.Sprintf("Wave %d - %d left", s.Wave, s.Remaining())
+	}
+	fontSize := int32(20)
+	w := rl.MeasureText(text, fontSize)
+	x, y := ui.Place(ui.TopCenter, w+16, fontSize+8, 0, 12, screenWidth, screenHeight)
+	rl.DrawRectangle(x, y, w+16, fontSize+8, rl.Fade(rl.Black, 0.5))
+	rl.DrawText(text, x+8, y+4, fontSize, rl.White)
+}
+
+func abs32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,261 @@
+package crafting
+
+import (
+	"fmt"
+	"log"
+	"platformer-game/database"
+	"platformer-game/gameobjects"
+	"platformer-game/input"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Bench is the crafting panel: the recipes the player knows, and the one
+// being crafted right now.
+type Bench struct {
+	Recipes    []Recipe
+	Discovered map[string]bool // recipe IDs; a recipe is learnt the first time all its inputs are held
+	IsOpen     bool
+
+	// Make builds the inventory item for an output name.
+	Make func(name string) gameobjects.Item
+	// OnCrafted, if set, is called with the output name after each craft.
+	OnCrafted func(name string)
+
+	cursor  int        // highlighted row of Known()
+	job     *Recipe    // recipe being crafted, or nil
+	elapsed float32    // seconds spent on job
+	panel   rl.Vector2 // top-left of the panel, beside the inventory window
+}
+
+func NewBench(recipes []Recipe, mk func(name string) gameobjects.Item) *Bench {
+	return &Bench{Recipes: recipes, Discovered: map[string]bool{}, Make: mk}
+}
+
+// CanCraft reports whether inv holds everything r uses.
+func CanCraft(r Recipe, inv *gameobjects.Inventory) bool {
+	have := map[string]int{}
+	for _, it := range inv.Slots {
+		have[it.Name] += it.Quantity()
+	}
+	for name, n := range r.needs() {
+		if have[name] < n {
+			return false
+		}
+	}
+	return true
+}
+
+// Known returns the discovered recipes, in file order.
+func (b *Bench) Known() []Recipe {
+	var out []Recipe
+	for _, r := range b.Recipes {
+		if b.Discovered[r.ID] {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Crafting reports whether a recipe is in progress.
+func (b *Bench) Crafting() bool {
+	return b.job != nil
+}
+
+// Progress is how far the current craft is, from 0 to 1.
+func (b *Bench) Progress() float32 {
+	if b.job == nil || b.job.Time <= 0 {
+		return 0
+	}
+	return b.elapsed / b.job.Time
+}
+
+// Toggle opens or closes the panel. Closing it abandons the current craft.
+func (b *Bench) Toggle() {
+	b.IsOpen = !b.IsOpen
+	if !b.IsOpen {
+		b.Cancel()
+	}
+}
+
+// Cancel abandons the current craft; nothing has been used up yet.
+func (b *Bench) Cancel() {
+	if b.job != nil {
+		log.Printf("Stopped crafting %s\n", b.job.Output)
+	}
+	b.job = nil
+	b.elapsed = 0
+}
+
+// Start begins crafting r if inv holds its inputs and nothing else is being made.
+func (b *Bench) Start(r Recipe, inv *gameobjects.Inventory) bool {
+	if b.job != nil || !CanCraft(r, inv) {
+		return false
+	}
+	b.job = &r
+	b.elapsed = 0
+	log.Printf("Crafting %s...\n", r.Output)
+	return true
+}
+
+// Update learns new recipes from what inv holds, advances the current craft
+// and, while the panel is open, handles input:
+//   - Up/Down: move the highlight
+//   - Enter/Space or left-click a row: craft it
+func (b *Bench) Update(inv *gameobjects.Inventory, dt float32) {
+	b.discover(inv)
+
+	if b.job != nil {
+		b.elapsed += dt
+		if b.elapsed >= b.job.Time {
+			b.finish(inv)
+		}
+	}
+
+	if !b.IsOpen {
+		return
+	}
+	known := b.Known()
+	if len(known) == 0 {
+		return
+	}
+	if input.Pressed(input.MenuDown) {
+		b.cursor = (b.cursor + 1) % len(known)
+	}
+	if input.Pressed(input.MenuUp) {
+		b.cursor = (b.cursor - 1 + len(known)) % len(known)
+	}
+	if b.cursor >= len(known) {
+		b.cursor = 0
+	}
+	b.panel = inv.PanelOrigin(panelW)
+	if input.Pressed(input.Click) {
+		m := input.Mouse()
+		for i := range known {
+			if rl.CheckCollisionPointRec(m, b.rowRect(i)) {
+				b.cursor = i
+				b.Start(known[i], inv)
+			}
+		}
+	}
+	if input.Pressed(input.Confirm) {
+		b.Start(known[b.cursor], inv)
+	}
+}
+
+// finish uses up the inputs and hands over the output. If the inputs were
+// moved away mid-craft, the craft fails and nothing is used.
+func (b *Bench) finish(inv *gameobjects.Inventory) {
+	r := *b.job
+	b.job = nil
+	b.elapsed = 0
+	if !CanCraft(r, inv) {
+		log.Printf("Couldn't finish %s: ingredients missing\n", r.Output)
+		return
+	}
+	for _, in := range r.Inputs {
+		inv.RemoveItem(in)
+	}
+	if !inv.AddItem(b.Make(r.Output)) {
+		// Using up part of a stack frees no slot; hand the inputs back
+		for _, in := range r.Inputs {
+			inv.AddItem(b.Make(in))
+		}
+		inv.SaveToDB()
+		log.Printf("No room for the %s\n", r.Output)
+		return
+	}
+	inv.SaveToDB()
+	log.Println("Crafted:", r.Output)
+	if b.OnCrafted != nil {
+		b.OnCrafted(r.Output)
+	}
+}
+
+// discover learns every recipe whose inputs are all in inv.
+func (b *Bench) discover(inv *gameobjects.Inventory) {
+	for _, r := range b.Recipes {
+		if !b.Discovered[r.ID] && CanCraft(r, inv) {
+			b.Discovered[r.ID] = true
+			log.Printf("New recipe: %s\n", r.Output)
+			b.saveDiscovery(r.ID)
+		}
+	}
+}
+
+// ─── Drawing ───
+
+const (
+	panelW = 330
+	rowH   = 22
+)
+
+func (b *Bench) rowRect(i int) rl.Rectangle {
+	return rl.NewRectangle(b.panel.X+8, b.panel.Y+float32(32+i*rowH), panelW-16, rowH-2)
+}
+
+// Draw renders the panel (screen space) next to the inventory grid.
+func (b *Bench) Draw(inv *gameobjects.Inventory) {
+	if !b.IsOpen {
+		return
+	}
+	b.panel = inv.PanelOrigin(panelW)
+	panelX, panelY := int32(b.panel.X), int32(b.panel.Y)
+	known := b.Known()
+	h := int32(32 + rowH*len(known) + 50)
+	rl.DrawRectangle(panelX, panelY, panelW, h, rl.Fade(rl.Black, 0.85))
+	rl.DrawRectangleLines(panelX, panelY, panelW, h, rl.RayWhite)
+	rl.DrawText("Crafting", panelX+8, panelY+8, 16, rl.RayWhite)
+
+	for i, r := range known {
+		rect := b.rowRect(i)
+		if i == b.cursor {
+			rl.DrawRectangleRec(rect, rl.Fade(rl.Yellow, 0.25))
+		}
+		col := rl.Gray
+		if CanCraft(r, inv) {
+			col = rl.White
+		}
+		label := fmt.Sprintf("%s  (%s)  %.0fs", r.Output, strings.Join(r.Inputs, " + "), r.Time)
+		rl.DrawText(label, int32(rect.X)+4, int32(rect.Y)+4, 12, col)
+	}
+
+	y := panelY + int32(32+rowH*len(known)+6)
+	if b.job != nil {
+		const barW = panelW - 16
+		rl.DrawText("Crafting "+b.job.Output+"...", panelX+8, y, 12, rl.Gold)
+		rl.DrawRectangle(panelX+8, y+16, barW, 8, rl.DarkGray)
+		rl.DrawRectangle(panelX+8, y+16, int32(barW*b.Progress()), 8, rl.Gold)
+	} else if left := len(b.Recipes) - len(known); left > 0 {
+		rl.DrawText(fmt.Sprintf("%d recipe(s) still to discover", left), panelX+8, y, 12, rl.Gray)
+	}
+}
+
+// ─── Persistence ───
+
+// LoadFromDB restores which recipes the player has discovered.
+func (b *Bench) LoadFromDB() {
+	rows, err := database.DB.Query(`SELECT recipe_id FROM recipes`)
+	if err != nil {
+		log.Println("Failed to load recipes from database:", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			log.Println("Error scanning recipe row:", err)
+			continue
+		}
+		b.Discovered[id] = true
+	}
+}
+
+func (b *Bench) saveDiscovery(id string) {
+	_, err := database.DB.Exec(`INSERT OR IGNORE INTO recipes (recipe_id) VALUES (?);`, id)
+	if err != nil {
+		log.Println("Failed to save recipe:", err)
+	}
+}
@@ -0,0 +1,49 @@
+// Package crafting turns inventory items into new ones using recipes loaded
+// from data.
+package crafting
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Recipe uses up its inputs and, after Time seconds, gives one Output.
+type Recipe struct {
+	ID     string   `json:"id"`
+	Inputs []string `json:"inputs"` // item names; repeat a name to need more than one
+	Output string   `json:"output"`
+	Time   float32  `json:"time"` // seconds to craft
+}
+
+// needs counts how many of each item the recipe uses.
+func (r Recipe) needs() map[string]int {
+	n := map[string]int{}
+	for _, in := range r.Inputs {
+		n[in]++
+	}
+	return n
+}
+
+// LoadRecipes reads a JSON array of recipes from path.
+func LoadRecipes(path string) ([]Recipe, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var recipes []Recipe
+	if err := json.Unmarshal(data, &recipes); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	seen := map[string]bool{}
+	for _, r := range recipes {
+		switch {
+		case r.ID == "" || r.Output == "" || len(r.Inputs) == 0:
+			return nil, fmt.Errorf("%s: recipe %q needs an id, inputs and an output", path, r.ID)
+		case seen[r.ID]:
+			return nil, fmt.Errorf("%s: duplicate recipe %q", path, r.ID)
+		}
+		seen[r.ID] = true
+	}
+	return recipes, nil
+}
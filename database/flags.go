@@ -0,0 +1,34 @@
+package database
+
+import "log"
+
+// SaveFlag records a story flag being set or cleared.
+func SaveFlag(name string, set bool) {
+	query := `INSERT OR IGNORE INTO flags (flag) VALUES (?);`
+	if !set {
+		query = `DELETE FROM flags WHERE flag = ?;`
+	}
+	if _, err := DB.Exec(query, name); err != nil {
+		log.Printf("Failed to save flag %s: %v\n", name, err)
+	}
+}
+
+// LoadFlags returns the story flags that are set.
+func LoadFlags() map[string]bool {
+	flags := map[string]bool{}
+	rows, err := DB.Query(`SELECT flag FROM flags`)
+	if err != nil {
+		log.Println("Failed to load flags from database:", err)
+		return flags
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			log.Println("Failed to read flag:", err)
+			continue
+		}
+		flags[name] = true
+	}
+	return flags
+}
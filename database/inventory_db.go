@@ -4,13 +4,27 @@ import (
 	"database/sql"
 	_ "github.com/mattn/go-sqlite3"
 	"log"
+	"os"
+	"path/filepath"
 )
 
 var DB *sql.DB
 
+// Profile names the player whose progress (e.g. coins, the run's seed) is
+// loaded and saved. There's only one for now.
+var Profile = "default"
+
+// Path is the database holding the game in progress. Everything the game
+// saves goes here as it happens. The game sets it from its config; recorded
+// and replayed runs point it at a scratch file instead.
+var Path = "./game_data.db"
+
 func InitDatabase() {
+	if err := os.MkdirAll(filepath.Dir(Path), 0o755); err != nil {
+		log.Fatal("Failed to create the save folder:", err)
+	}
 	var err error
-	DB, err = sql.Open("sqlite3", "./game_data.db")
+	DB, err = sql.Open("sqlite3", Path)
 	if err != nil {
 		log.Fatal("Failed to open database:", err)
 	}
@@ -26,4 +40,130 @@ func InitDatabase() {
 	if err != nil {
 		log.Fatal("Failed to create inventory table:", err)
 	}
+	addColumn("inventory", "durability", "INTEGER DEFAULT 0")
+	addColumn("inventory", "count", "INTEGER DEFAULT 0")
+
+	createQuestsTable := `
+	CREATE TABLE IF NOT EXISTS quests (
+		quest_id TEXT,
+		objective INTEGER,
+		progress INTEGER,
+		PRIMARY KEY (quest_id, objective)
+	);`
+
+	_, err = DB.Exec(createQuestsTable)
+	if err != nil {
+		log.Fatal("Failed to create quests table:", err)
+	}
+
+	createContainersTable := `
+	CREATE TABLE IF NOT EXISTS containers (
+		container_id TEXT,
+		slot INTEGER,
+		type INTEGER,
+		name TEXT,
+		PRIMARY KEY (container_id, slot)
+	);`
+
+	_, err = DB.Exec(createContainersTable)
+	if err != nil {
+		log.Fatal("Failed to create containers table:", err)
+	}
+	addColumn("containers", "durability", "INTEGER DEFAULT 0")
+	addColumn("containers", "count", "INTEGER DEFAULT 0")
+
+	createRecipesTable := `
+	CREATE TABLE IF NOT EXISTS recipes (
+		recipe_id TEXT PRIMARY KEY
+	);`
+
+	_, err = DB.Exec(createRecipesTable)
+	if err != nil {
+		log.Fatal("Failed to create recipes table:", err)
+	}
+
+	createEquipmentTable := `
+	CREATE TABLE IF NOT EXISTS equipment (
+		slot INTEGER PRIMARY KEY,
+		name TEXT,
+		durability INTEGER
+	);`
+
+	_, err = DB.Exec(createEquipmentTable)
+	if err != nil {
+		log.Fatal("Failed to create equipment table:", err)
+	}
+
+	createShopTables := `
+	CREATE TABLE IF NOT EXISTS shops (
+		shop_id TEXT PRIMARY KEY,
+		restock_left REAL
+	);
+	CREATE TABLE IF NOT EXISTS shop_stock (
+		shop_id TEXT,
+		item TEXT,
+		quantity INTEGER,
+		PRIMARY KEY (shop_id, item)
+	);
+	CREATE TABLE IF NOT EXISTS wallet (
+		profile TEXT PRIMARY KEY,
+		coins INTEGER
+	);`
+
+	_, err = DB.Exec(createShopTables)
+	if err != nil {
+		log.Fatal("Failed to create shop tables:", err)
+	}
+
+	createRunTable := `
+	CREATE TABLE IF NOT EXISTS run (
+		profile TEXT PRIMARY KEY,
+		seed INTEGER
+	);`
+
+	_, err = DB.Exec(createRunTable)
+	if err != nil {
+		log.Fatal("Failed to create run table:", err)
+	}
+	addColumn("run", "attempt", "INTEGER DEFAULT 0")
+
+	createUnlockedTable := `
+	CREATE TABLE IF NOT EXISTS unlocked (
+		kind TEXT,
+		id TEXT,
+		PRIMARY KEY (kind, id)
+	);`
+
+	_, err = DB.Exec(createUnlockedTable)
+	if err != nil {
+		log.Fatal("Failed to create unlocked table:", err)
+	}
+
+	createFlagsTable := `
+	CREATE TABLE IF NOT EXISTS flags (
+		flag TEXT PRIMARY KEY
+	);`
+
+	_, err = DB.Exec(createFlagsTable)
+	if err != nil {
+		log.Fatal("Failed to create flags table:", err)
+	}
+}
+
+// addColumn adds a column to a table created by an older version of the game.
+func addColumn(table, column, decl string) {
+	rows, err := DB.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		log.Fatal("Failed to read columns of "+table+":", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if rows.Scan(&name) == nil && name == column {
+			return
+		}
+	}
+	if _, err := DB.Exec(`ALTER TABLE ` + table + ` ADD COLUMN ` + column + ` ` + decl); err != nil {
+		log.Fatal("Failed to add "+column+" to "+table+":", err)
+	}
 }
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Save slots are copies of the live database the player can go back to. They
+// are kept in a folder beside it.
+const (
+	savesDir = "saves"
+	NumSlots = 3
+)
+
+func slotPath(slot int) string {
+	return filepath.Join(filepath.Dir(Path), savesDir, fmt.Sprintf("slot%d.db", slot+1))
+}
+
+// Exists reports whether a game has been saved at Path.
+func Exists() bool {
+	_, err := os.Stat(Path)
+	return err == nil
+}
+
+// SlotSaved returns when a slot was last saved, and false if it's empty.
+func SlotSaved(slot int) (time.Time, bool) {
+	fi, err := os.Stat(slotPath(slot))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return fi.ModTime(), true
+}
+
+// SaveSlot copies the game in progress into a slot, replacing what was there.
+func SaveSlot(slot int) error {
+	path := slotPath(slot)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	_, err := DB.Exec(`VACUUM INTO ?`, path)
+	return err
+}
+
+// LoadSlot makes a copy of a slot the game in progress.
+func LoadSlot(slot int) error {
+	data, err := os.ReadFile(slotPath(slot))
+	if err != nil {
+		return err
+	}
+	DB.Close()
+	err = os.WriteFile(Path, data, 0o644)
+	InitDatabase() // reopen even if the copy failed, so the game can carry on
+	return err
+}
+
+// Reset throws the game in progress away and starts an empty database.
+func Reset() error {
+	DB.Close()
+	err := os.Remove(Path)
+	InitDatabase()
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// LastSlot returns the most recently saved slot, and false if none is saved.
+func LastSlot() (int, bool) {
+	last, found := 0, false
+	var newest time.Time
+	for i := 0; i < NumSlots; i++ {
+		if t, ok := SlotSaved(i); ok && (!found || t.After(newest)) {
+			last, newest, found = i, t, true
+		}
+	}
+	return last, found
+}
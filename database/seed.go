@@ -0,0 +1,34 @@
+package database
+
+import (
+	"database/sql"
+	"log"
+)
+
+// SaveSeed records the seed the current profile's run was started from and
+// how many times it has been started again since, so the run (and every
+// save slot copied from it) can be played again.
+func SaveSeed(seed int64, attempt int) {
+	_, err := DB.Exec(`
+		INSERT OR REPLACE INTO run (profile, seed, attempt)
+		VALUES (?, ?, ?);`,
+		Profile, seed, attempt)
+	if err != nil {
+		log.Println("Failed to save seed:", err)
+	}
+}
+
+// LoadSeed returns the current profile's seed and attempt, and false if the
+// game was started before seeds were saved (or not at all).
+func LoadSeed() (int64, int, bool) {
+	var seed int64
+	var attempt int
+	err := DB.QueryRow(`SELECT seed, attempt FROM run WHERE profile = ?`, Profile).Scan(&seed, &attempt)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Failed to load seed from database:", err)
+		}
+		return 0, 0, false
+	}
+	return seed, attempt, true
+}
@@ -0,0 +1,30 @@
+package database
+
+import (
+	"database/sql"
+	"log"
+)
+
+// SaveUnlocked records that the door or container (kind "door" or
+// "container") with the given ID has been unlocked, so it stays unlocked
+// when the game is loaded again.
+func SaveUnlocked(kind, id string) {
+	_, err := DB.Exec(`INSERT OR IGNORE INTO unlocked (kind, id) VALUES (?, ?);`, kind, id)
+	if err != nil {
+		log.Printf("Failed to save unlocked %s %s: %v\n", kind, id, err)
+	}
+}
+
+// Unlocked reports whether the door or container with the given ID was
+// saved as unlocked.
+func Unlocked(kind, id string) bool {
+	var n int
+	err := DB.QueryRow(`SELECT 1 FROM unlocked WHERE kind = ? AND id = ?`, kind, id).Scan(&n)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("Failed to load unlocked %s %s: %v\n", kind, id, err)
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,263 @@
+package dialogue
+
+import (
+	"fmt"
+	"log"
+	"platformer-game/input"
+	"strings"
+	"unicode/utf8"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// World is what conversations can look at and change in the game.
+type World interface {
+	HasItem(name string) bool
+	GiveItem(name string)
+	TakeItem(name string)
+	UnlockDoor(id string)
+}
+
+const (
+	typeSpeed = 40 // characters revealed per second
+	maxSteps  = 32 // branch hops before we assume a loop and bail out
+)
+
+// Runner plays one conversation at a time and keeps the story flags.
+type Runner struct {
+	World World
+	Flags map[string]bool
+
+	script  *Script
+	node    *Node
+	choices []Choice // the current node's choices whose conditions hold
+	shown   float32  // characters of node.Text revealed so far
+	cursor  int      // highlighted choice
+
+	// OnEnd, if set, is called with the script ID when a conversation finishes.
+	OnEnd func(id string)
+	// OnFlag, if set, is called when a conversation sets or clears a flag,
+	// so the game can save it.
+	OnFlag func(name string, set bool)
+}
+
+func NewRunner(w World) *Runner {
+	return &Runner{World: w, Flags: map[string]bool{}}
+}
+
+// Active reports whether a conversation is on screen.
+func (r *Runner) Active() bool {
+	return r.node != nil
+}
+
+// Start begins s at its start node. Does nothing if s is nil.
+func (r *Runner) Start(s *Script) {
+	if s == nil {
+		return
+	}
+	r.script = s
+	r.enter(s.Start)
+}
+
+// enter shows node id, following branches and running its actions.
+func (r *Runner) enter(id string) {
+	for step := 0; step < maxSteps; step++ {
+		n, ok := r.script.Nodes[id]
+		if !ok {
+			r.end()
+			return
+		}
+		jumped := false
+		for _, b := range n.Branches {
+			if r.holds(b.If) {
+				id = b.To
+				jumped = true
+				break
+			}
+		}
+		if jumped {
+			continue
+		}
+
+		r.node = n
+		r.shown = 0
+		r.cursor = 0
+		r.choices = r.choices[:0]
+		for _, c := range n.Choices {
+			if r.holds(c.If) {
+				r.choices = append(r.choices, c)
+			}
+		}
+		r.run(n.Actions)
+		return
+	}
+	log.Printf("Dialogue %s: too many branch hops, giving up\n", r.script.ID)
+	r.end()
+}
+
+func (r *Runner) end() {
+	id := r.script.ID
+	r.script = nil
+	r.node = nil
+	r.choices = nil
+	if r.OnEnd != nil {
+		r.OnEnd(id)
+	}
+}
+
+// holds reports whether every condition is true.
+func (r *Runner) holds(conds []Condition) bool {
+	for _, c := range conds {
+		switch {
+		case c.HasItem != "" && !r.World.HasItem(c.HasItem):
+			return false
+		case c.MissingItem != "" && r.World.HasItem(c.MissingItem):
+			return false
+		case c.Flag != "" && !r.Flags[c.Flag]:
+			return false
+		case c.NotFlag != "" && r.Flags[c.NotFlag]:
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Runner) run(actions []Action) {
+	for _, a := range actions {
+		switch {
+		case a.GiveItem != "":
+			r.World.GiveItem(a.GiveItem)
+		case a.TakeItem != "":
+			r.World.TakeItem(a.TakeItem)
+		case a.SetFlag != "":
+			r.Flags[a.SetFlag] = true
+			r.flagChanged(a.SetFlag, true)
+		case a.ClearFlag != "":
+			delete(r.Flags, a.ClearFlag)
+			r.flagChanged(a.ClearFlag, false)
+		case a.UnlockDoor != "":
+			r.World.UnlockDoor(a.UnlockDoor)
+		}
+	}
+}
+
+func (r *Runner) flagChanged(name string, set bool) {
+	if r.OnFlag != nil {
+		r.OnFlag(name, set)
+	}
+}
+
+// Update advances the typewriter and handles input:
+//   - Space/Enter: finish the line, then continue or pick the highlighted choice
+//   - Up/Down: move the highlight
+//   - 1-9: pick a choice directly
+func (r *Runner) Update(dt float32) {
+	if r.node == nil {
+		return
+	}
+	total := float32(utf8.RuneCountInString(r.node.Text))
+	if r.shown < total {
+		r.shown += typeSpeed * dt
+	}
+	done := r.shown >= total
+	confirm := input.Pressed(input.Confirm)
+
+	if !done {
+		if confirm {
+			r.shown = total
+		}
+		return
+	}
+
+	if len(r.choices) == 0 {
+		if confirm {
+			r.enter(r.node.Next)
+		}
+		return
+	}
+
+	if input.Pressed(input.MenuDown) {
+		r.cursor = (r.cursor + 1) % len(r.choices)
+	}
+	if input.Pressed(input.MenuUp) {
+		r.cursor = (r.cursor - 1 + len(r.choices)) % len(r.choices)
+	}
+	for i := range r.choices {
+		if input.Pressed(input.Slot(i)) {
+			r.cursor = i
+			confirm = true
+		}
+	}
+	if confirm {
+		c := r.choices[r.cursor]
+		r.run(c.Actions)
+		r.enter(c.Next)
+	}
+}
+
+// Draw renders the dialogue box along the bottom of a screenW×screenH screen.
+func (r *Runner) Draw(screenW, screenH int32) {
+	if r.node == nil {
+		return
+	}
+	const (
+		margin   = 10
+		padding  = 10
+		fontSize = 18
+		lineH    = 22
+	)
+	w := screenW - 2*margin
+	lines := wrap(r.node.Text, fontSize, w-2*padding)
+	boxH := int32(padding*2 + lineH*(1+len(lines)) + lineH*len(r.choices))
+	x, y := int32(margin), screenH-boxH-margin
+
+	rl.DrawRectangle(x, y, w, boxH, rl.Fade(rl.Black, 0.8))
+	rl.DrawRectangleLines(x, y, w, boxH, rl.RayWhite)
+
+	ty := y + padding
+	if r.node.Speaker != "" {
+		rl.DrawText(r.node.Speaker, x+padding, ty, fontSize, rl.Gold)
+		ty += lineH
+	}
+	n := int(r.shown) // characters to show, spread over the lines
+	for _, line := range lines {
+		chars := []rune(line)
+		rl.DrawText(string(chars[:min(n, len(chars))]), x+padding, ty, fontSize, rl.White)
+		n = max(n-len(chars)-1, 0) // -1 for the space the line broke at
+		ty += lineH
+	}
+
+	if r.shown < float32(utf8.RuneCountInString(r.node.Text)) {
+		return
+	}
+	for i, c := range r.choices {
+		col := rl.LightGray
+		if i == r.cursor {
+			col = rl.Yellow
+		}
+		rl.DrawText(fmt.Sprintf("%d. %s", i+1, c.Text), x+padding*2, ty, fontSize, col)
+		ty += lineH
+	}
+	if len(r.choices) == 0 {
+		rl.DrawText("[Space]", x+w-padding-rl.MeasureText("[Space]", 12), y+boxH-padding-12, 12, rl.Gray)
+	}
+}
+
+// wrap breaks text into lines no wider than width at fontSize, between words.
+// A word too long for a line gets a line to itself.
+func wrap(text string, fontSize, width int32) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == "":
+			line = word
+		case rl.MeasureText(line+" "+word, fontSize) > width:
+			lines = append(lines, line)
+			line = word
+		default:
+			line += " " + word
+		}
+	}
+	return append(lines, line)
+}
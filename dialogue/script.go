@@ -0,0 +1,125 @@
+// Package dialogue runs branching conversations.
+//
+// A conversation is a Script loaded from a JSON file: a set of named Nodes,
+// each with a speaker, a line of text and either a Next node or a list of
+// Choices. Conditions (on inventory items and story flags) hide choices or
+// redirect nodes, and Actions let a line give or take items, set flags and
+// unlock doors. The game side of those is behind the World interface, so this
+// package never touches gameobjects directly.
+package dialogue
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Script is one conversation.
+type Script struct {
+	ID    string           `json:"-"` // file name without extension
+	Start string           `json:"start"`
+	Nodes map[string]*Node `json:"nodes"`
+}
+
+// Node is one line of dialogue.
+type Node struct {
+	Speaker  string   `json:"speaker"`
+	Text     string   `json:"text"`
+	Branches []Branch `json:"branches,omitempty"` // checked on entry; the first match jumps elsewhere
+	Actions  []Action `json:"actions,omitempty"`  // run when the node is shown
+	Choices  []Choice `json:"choices,omitempty"`
+	Next     string   `json:"next,omitempty"` // used when there are no choices; "" ends the conversation
+}
+
+// Branch redirects to another node when all of its conditions hold.
+type Branch struct {
+	If []Condition `json:"if"`
+	To string      `json:"to"`
+}
+
+// Choice is an answer the player can pick.
+type Choice struct {
+	Text    string      `json:"text"`
+	If      []Condition `json:"if,omitempty"` // hidden unless all hold
+	Actions []Action    `json:"actions,omitempty"`
+	Next    string      `json:"next,omitempty"` // "" ends the conversation
+}
+
+// Condition is a single check; set exactly one field.
+type Condition struct {
+	HasItem     string `json:"has_item,omitempty"`
+	MissingItem string `json:"missing_item,omitempty"`
+	Flag        string `json:"flag,omitempty"`
+	NotFlag     string `json:"not_flag,omitempty"`
+}
+
+// Action is a single side effect; set exactly one field.
+type Action struct {
+	GiveItem   string `json:"give_item,omitempty"`
+	TakeItem   string `json:"take_item,omitempty"`
+	SetFlag    string `json:"set_flag,omitempty"`
+	ClearFlag  string `json:"clear_flag,omitempty"`
+	UnlockDoor string `json:"unlock_door,omitempty"`
+}
+
+// LoadScripts reads every *.json file in dir. Broken files are logged and skipped.
+func LoadScripts(dir string) map[string]*Script {
+	scripts := map[string]*Script{}
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		log.Println("Failed to list dialogue scripts:", err)
+		return scripts
+	}
+	for _, f := range files {
+		s, err := LoadScript(f)
+		if err != nil {
+			log.Printf("Skipping dialogue script %s: %v\n", f, err)
+			continue
+		}
+		scripts[s.ID] = s
+	}
+	return scripts
+}
+
+// LoadScript reads a single script file.
+func LoadScript(path string) (*Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Script
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	s.ID = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	s.check()
+	return &s, nil
+}
+
+// check logs links to nodes that don't exist; they just end the conversation.
+func (s *Script) check() {
+	missing := func(id string) bool {
+		_, ok := s.Nodes[id]
+		return id != "" && !ok
+	}
+	if _, ok := s.Nodes[s.Start]; !ok {
+		log.Printf("Dialogue %s: start node %q does not exist\n", s.ID, s.Start)
+	}
+	for name, n := range s.Nodes {
+		if missing(n.Next) {
+			log.Printf("Dialogue %s: node %q points at missing node %q\n", s.ID, name, n.Next)
+		}
+		for _, b := range n.Branches {
+			if missing(b.To) {
+				log.Printf("Dialogue %s: node %q branches to missing node %q\n", s.ID, name, b.To)
+			}
+		}
+		for _, c := range n.Choices {
+			if missing(c.Next) {
+				log.Printf("Dialogue %s: choice %q points at missing node %q\n", s.ID, c.Text, c.Next)
+			}
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package gameobjects
+
+import "platformer-game/ai"
+
+// NewBoss builds the end-of-level boss: a huge zombie on the boss stat block
+// with a phase-based brain and senses that cover the whole arena.
+func NewBoss(x, y float32) Zombie {
+	z := InitZombie(x, y, ZombieBoss)
+	z.IsBoss = true
+	z.Brain = ai.NewBossBrain(ai.DefaultBossConfig())
+	z.Senses.SightRange = 1200
+	z.Senses.SightHalfAngle = 180 // it knows exactly where you are
+	z.Senses.Memory = 30
+	return z
+}
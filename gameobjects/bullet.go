@@ -5,10 +5,10 @@ import (
 )
 
 type Bullet struct {
-	Position rl.Vector2
-	Speed    float32
+	Position  rl.Vector2
+	Speed     float32
 	Direction rl.Vector2 // Vector indicating direction
-	IsActive bool        // Track if the bullet is active
+	IsActive  bool       // Track if the bullet is active
 }
 
 // Initialize a new bullet based on the player’s position and facing direction
@@ -32,6 +32,6 @@ func (b *Bullet) Update() {
 
 func (b *Bullet) Draw() {
 	if b.IsActive {
-		rl.DrawCircleV(b.Position, 5, rl.Red) 
+		rl.DrawCircleV(b.Position, 5, rl.Red)
 	}
 }
@@ -0,0 +1,226 @@
+package gameobjects
+
+import (
+	"log"
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// CompanionWeapon is what a companion hits zombies with.
+type CompanionWeapon struct {
+	Name     string
+	Damage   int
+	Range    float32 // how close a zombie has to be to get hit
+	Cooldown float32 // seconds between swings
+}
+
+// Pipe is the companion's starting weapon.
+var Pipe = CompanionWeapon{Name: "Pipe", Damage: 20, Range: 70, Cooldown: 0.8}
+
+const (
+	companionFollowDistance = 90   // how far behind the player the companion likes to be
+	companionFollowSlack    = 25   // don't bother moving for less than this
+	companionCatchUpDist    = 300  // further than this from the player: run
+	companionWalkSpeed      = 0.05 // per frame, same as the player walking
+	companionRunSpeed       = 0.2  // per frame, same as the player running
+	companionAggroRange     = 250  // zombies this close to the companion get attacked
+	companionLeash          = 400  // never chase a zombie further than this from the player
+	companionHitRange       = 70   // zombie strikes on the companion have to be this close
+	companionGrudge         = 3.0  // seconds a zombie the companion hit goes after it
+	companionReviveRange    = 80   // the player has to be this close to revive
+	companionReviveTime     = 2.0  // seconds of holding the revive key
+)
+
+// ActiveCompanion is the companion currently in the world (nil = none). Like
+// PlayerInstance, zombies look it up directly to hunt it and strike it.
+var ActiveCompanion *Companion
+
+// Companion is a friendly survivor that follows the player and fights zombies.
+// Visually it is a zombie body with a different tint, so it reuses all of the
+// zombie sprite loading and animation code.
+type Companion struct {
+	Body      Zombie // sprites, animation state and position
+	Name      string
+	Weapon    CompanionWeapon
+	Health    float64
+	MaxHealth float64
+
+	Downed         bool    // knocked out; needs the player to revive it
+	ReviveProgress float32 // seconds the revive key has been held
+	Waiting        bool    // told to stay put (e.g. left outside a door)
+	WaitX          float32 // where to wait
+	swingTimer     float32 // time until the next swing
+}
+
+// NewCompanion creates a companion standing at (x, y). y is where a walker
+// zombie would stand.
+func NewCompanion(name string, x, y float32) *Companion {
+	body := InitZombie(x, y, ZombieWalker)
+	body.Color = rl.SkyBlue
+	body.Brain = nil
+	return &Companion{
+		Body:      body,
+		Name:      name,
+		Weapon:    Pipe,
+		Health:    80,
+		MaxHealth: 80,
+	}
+}
+
+// WaitAt makes the companion walk to x and stay there until Follow is called.
+func (c *Companion) WaitAt(x float32) {
+	c.Waiting = true
+	c.WaitX = x
+}
+
+// Follow makes the companion go back to following the player.
+func (c *Companion) Follow() {
+	c.Waiting = false
+}
+
+// Update moves the companion, swings at zombies and handles being revived.
+// Closed doors in doors block it. reviveHeld is whether the player is holding
+// the revive key this frame.
+func (c *Companion) Update(player *Player, zombies []*Zombie, doors []*Door, reviveHeld bool, worldWidth int, dt float32) {
+	if c.Downed {
+		c.updateDowned(player, reviveHeld, dt)
+		return
+	}
+	if c.swingTimer > 0 {
+		c.swingTimer -= dt
+	}
+
+	// Fight first: the nearest zombie in reach of both us and the player
+	if z := c.nearestZombie(player, zombies); z != nil {
+		dx := z.Position.X - c.Body.Position.X
+		c.Body.FacingRight = dx > 0
+		if float32(math.Abs(float64(dx))) <= c.Weapon.Range {
+			c.Body.setState(ZombieAttacking)
+			if c.swingTimer <= 0 {
+				c.swingTimer = c.Weapon.Cooldown
+				z.TakeDamage(c.Weapon.Damage)
+				z.grudge = companionGrudge
+			}
+			return
+		}
+		c.moveTowards(z.Position.X, companionRunSpeed, doors, worldWidth)
+		return
+	}
+
+	// Otherwise tag along behind the player (or stay where we were told)
+	goal := c.WaitX
+	if !c.Waiting {
+		goal = player.Position.X - companionFollowDistance
+		if !player.FacingRight {
+			goal = player.Position.X + companionFollowDistance
+		}
+	}
+	dist := float32(math.Abs(float64(goal - c.Body.Position.X)))
+	if dist < companionFollowSlack {
+		c.Body.setState(ZombieIdle)
+		c.Body.FacingRight = player.Position.X > c.Body.Position.X
+		return
+	}
+	speed := float32(companionWalkSpeed)
+	if dist > companionCatchUpDist {
+		speed = companionRunSpeed
+	}
+	c.moveTowards(goal, speed, doors, worldWidth)
+}
+
+func (c *Companion) updateDowned(player *Player, reviveHeld bool, dt float32) {
+	near := rl.Vector2Distance(player.Position, c.Body.Position) <= companionReviveRange
+	if !near || !reviveHeld {
+		c.ReviveProgress = 0
+		return
+	}
+	c.ReviveProgress += dt
+	if c.ReviveProgress >= companionReviveTime {
+		c.Downed = false
+		c.ReviveProgress = 0
+		c.Health = c.MaxHealth / 2
+		c.Body.setState(ZombieIdle)
+		log.Println(c.Name, "is back on their feet")
+	}
+}
+
+// nearestZombie returns the closest live zombie that is in aggro range of the
+// companion and not too far from the player, or nil.
+func (c *Companion) nearestZombie(player *Player, zombies []*Zombie) *Zombie {
+	var best *Zombie
+	bestDist := float32(companionAggroRange)
+	for _, z := range zombies {
+		if !z.IsAlive {
+			continue
+		}
+		d := rl.Vector2Distance(c.Body.Position, z.Position)
+		if d <= bestDist && rl.Vector2Distance(player.Position, z.Position) <= companionLeash {
+			best, bestDist = z, d
+		}
+	}
+	return best
+}
+
+// moveTowards walks towards x, stopping in front of any closed door.
+func (c *Companion) moveTowards(x, speed float32, doors []*Door, worldWidth int) {
+	dir := float32(1)
+	if x < c.Body.Position.X {
+		dir = -1
+	}
+	next := c.Body.Position.X + dir*speed
+	for _, d := range doors {
+		if d.State == DoorOpen {
+			continue
+		}
+		if dir > 0 && c.Body.Position.X <= d.Position.X && next > d.Position.X {
+			next = d.Position.X
+		}
+		if dir < 0 && c.Body.Position.X >= d.Position.X+d.Width && next < d.Position.X+d.Width {
+			next = d.Position.X + d.Width
+		}
+	}
+	c.Body.setState(ZombieWalking)
+	c.Body.FacingRight = dir > 0
+	c.Body.Position.X = clampZombieX(next, float32(worldWidth)-c.Body.Width)
+}
+
+// hitBy is called when a zombie's strike at the companion (or area attack)
+// lands; it only hurts if the companion is close enough to the zombie.
+func (c *Companion) hitBy(z *Zombie, radius float32) {
+	if c.Downed {
+		return
+	}
+	if radius <= 0 {
+		radius = companionHitRange
+	}
+	if rl.Vector2Distance(z.Position, c.Body.Position) > radius {
+		return
+	}
+	c.Health -= z.Damage
+	if c.Health > 0 {
+		return
+	}
+	c.Health = 0
+	c.Downed = true
+	c.Body.setState(ZombieDead)
+	log.Println(c.Name, "is down! Hold F next to them to revive")
+}
+
+// Draw draws the companion plus a small health bar (or revive bar when downed).
+func (c *Companion) Draw() {
+	c.Body.Draw()
+
+	const barW, barH = 50, 5
+	x := int32(c.Body.Position.X - barW/2)
+	y := int32(c.Body.Position.Y - c.Body.Height/2 - 12)
+	rl.DrawRectangle(x, y, barW, barH, rl.DarkGray)
+	if c.Downed {
+		frac := c.ReviveProgress / companionReviveTime
+		rl.DrawRectangle(x, y, int32(barW*frac), barH, rl.Yellow)
+		rl.DrawText("DOWN", x+8, y-14, 12, rl.Red)
+		return
+	}
+	frac := float32(c.Health / c.MaxHealth)
+	rl.DrawRectangle(x, y, int32(barW*frac), barH, rl.SkyBlue)
+}
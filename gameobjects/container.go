@@ -0,0 +1,112 @@
+package gameobjects
+
+import (
+	"log"
+	"platformer-game/database"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Container is a chest, cabinet or crate with its own item grid. Its contents
+// are saved to the containers table under its ID.
+type Container struct {
+	ID            string
+	Name          string
+	Position      rl.Vector2 // top-left corner in world coordinates
+	Width, Height float32
+	Locked        bool
+	RequiredKey   string // item name that unlocks it
+	IsOpen        bool
+	Inventory     Inventory
+}
+
+// NewContainer creates an empty, unlocked container standing on the ground at groundY.
+func NewContainer(id, name string, x, groundY float32, slots int) *Container {
+	c := &Container{
+		ID:        id,
+		Name:      name,
+		Position:  rl.NewVector2(x, groundY-40),
+		Width:     60,
+		Height:    40,
+		Inventory: NewInventory(slots),
+	}
+	c.Inventory.OnSave = c.SaveToDB
+	return c
+}
+
+// PlayerNear reports whether a player centred on playerPos can reach the container.
+func (c *Container) PlayerNear(playerPos rl.Vector2, playerWidth, playerHeight float32) bool {
+	rect := rl.Rectangle{X: c.Position.X - 30, Y: c.Position.Y, Width: c.Width + 60, Height: c.Height}
+	player := rl.Rectangle{X: playerPos.X - playerWidth/2, Y: playerPos.Y - playerHeight/2, Width: playerWidth, Height: playerHeight}
+	return rl.CheckCollisionRecs(rect, player)
+}
+
+// SaveToDB writes every slot (empty ones too) of the container.
+func (c *Container) SaveToDB() {
+	for i, item := range c.Inventory.Slots {
+		_, err := database.DB.Exec(`
+			INSERT OR REPLACE INTO containers (container_id, slot, type, name, durability, count)
+			VALUES (?, ?, ?, ?, ?, ?);`,
+			c.ID, i, item.Type, item.Name, item.Durability, item.Count)
+		if err != nil {
+			log.Println("Failed to save container item:", err)
+		}
+	}
+}
+
+// LoadFromDB restores the saved contents. Returns false if the container has
+// never been saved, so the caller can fill it with its starting items.
+func (c *Container) LoadFromDB(itemTextures map[string]rl.Texture2D) bool {
+	rows, err := database.DB.Query(`SELECT slot, type, name, durability, count FROM containers WHERE container_id = ?`, c.ID)
+	if err != nil {
+		log.Println("Failed to load container from database:", err)
+		return false
+	}
+	defer rows.Close()
+
+	found := false
+	for rows.Next() {
+		var slot int
+		var itemType ItemType
+		var name string
+		var durability, count int
+		if err := rows.Scan(&slot, &itemType, &name, &durability, &count); err != nil {
+			log.Println("Error scanning container row:", err)
+			continue
+		}
+		found = true
+		if slot < 0 || slot >= c.Inventory.MaxSlots || itemType == Other {
+			continue
+		}
+		c.Inventory.Slots[slot] = Item{Type: itemType, Name: name, Image: itemTextures[name], Durability: durability, Count: count}
+	}
+	return found
+}
+
+// Draw draws the container as a simple chest: lid up when open, a gold lock
+// while locked (world space).
+func (c *Container) Draw() {
+	x, y := int32(c.Position.X), int32(c.Position.Y)
+	w, h := int32(c.Width), int32(c.Height)
+	lidH := h / 3
+
+	rl.DrawRectangle(x, y+lidH, w, h-lidH, rl.Brown)
+	rl.DrawRectangleLines(x, y+lidH, w, h-lidH, rl.DarkBrown)
+	if c.IsOpen {
+		rl.DrawRectangle(x, y-lidH, w, lidH, rl.Brown)
+		rl.DrawRectangleLines(x, y-lidH, w, lidH, rl.DarkBrown)
+	} else {
+		rl.DrawRectangle(x, y, w, lidH, rl.Brown)
+		rl.DrawRectangleLines(x, y, w, lidH, rl.DarkBrown)
+	}
+	if c.Locked {
+		rl.DrawRectangle(x+w/2-5, y+lidH-4, 10, 10, rl.Gold)
+	}
+}
+
+// DrawContents draws the container's name and grid (screen space).
+func (c *Container) DrawContents(inv *Inventory) {
+	c.Inventory.Beside(inv)
+	rl.DrawText(c.Name, int32(c.Inventory.Origin.X), int32(c.Inventory.Origin.Y)-20, 16, rl.White)
+	c.Inventory.DrawInventory()
+}
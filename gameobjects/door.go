@@ -4,6 +4,8 @@ package gameobjects
 import (
 	"fmt"
 	rl "github.com/gen2brain/raylib-go/raylib"
+	"platformer-game/clock"
+	"platformer-game/input"
 	"platformer-game/rendering"
 	"time"
 )
@@ -15,10 +17,16 @@ const (
 	DoorClosed DoorState = iota
 	DoorOpening
 	DoorOpen
+	DoorClosing // opening animation played backwards
 )
 
 type Door struct {
-	ID            string
+	ID          string
+	RequiredKey string // item name that unlocks it ("" = no key needed)
+	Locked      bool   // closed and won't open without RequiredKey
+	Destination string // scene the door leads to ("" = not a way out)
+	SpawnX      float32
+
 	Position      rl.Vector2     // top‐left corner in world coordinates
 	Frames        []rl.Texture2D // door frames (closed → open)
 	State         DoorState
@@ -38,7 +46,7 @@ type Door struct {
 }
 
 // NewAnimatedDoor lets you specify exactly which sub‐rectangles to pull from the spritesheet.
-//   - id: unique door ID (e.g. "BronzeKey")
+//   - id: unique door ID (e.g. "HouseFront")
 //   - x, y: world position to draw the door
 //   - sheetPath: path to the PNG containing all door frames scattered anywhere
 //   - frameRects: a slice of rl.Rectangle, one per animation frame (closed→open). Each rect is in pixels.
@@ -72,53 +80,102 @@ func NewAnimatedDoor(
 		Frames:        allFrames,
 		State:         DoorClosed,
 		CurrentFrame:  0,
-		lastFrameTime: time.Now(),
+		lastFrameTime: clock.Now(),
 		FrameDelay:    time.Millisecond * time.Duration(delayMs),
 		Width:         w,
 		Height:        h,
 	}
 }
 
-// TryUnlock transitions a closed door into the "opening" animation.
+// TryUnlock unlocks the door and starts the "opening" animation.
 func (d *Door) TryUnlock() {
-	if d.State == DoorClosed {
+	d.Locked = false
+	d.Open()
+}
+
+// Open starts the opening animation if the door is closed and unlocked (a
+// closing door turns around mid-swing). Returns false if the door is locked.
+func (d *Door) Open() bool {
+	if d.Locked {
+		return false
+	}
+	switch d.State {
+	case DoorClosed:
 		d.State = DoorOpening
 		d.CurrentFrame = 0
-		d.lastFrameTime = time.Now()
+		d.lastFrameTime = clock.Now()
+	case DoorClosing:
+		d.State = DoorOpening
+		d.lastFrameTime = clock.Now()
 	}
+	return true
 }
 
-// Update advances the opening animation when enough time has passed.
-// Once the final frame is reached, State switches to DoorOpen.
-func (d *Door) Update() {
-	if d.State != DoorOpening {
-		return
+// StartClosing plays the opening animation backwards from wherever it is.
+func (d *Door) StartClosing() {
+	if d.State == DoorOpen || d.State == DoorOpening {
+		d.State = DoorClosing
+		d.lastFrameTime = clock.Now()
+		d.MenuOpen = false
+	}
+}
+
+// SetOpen snaps the door fully open.
+func (d *Door) SetOpen() {
+	d.State = DoorOpen
+	d.CurrentFrame = len(d.Frames) - 1
+}
+
+// Close snaps the door shut (e.g. to lock the player into a boss arena).
+func (d *Door) Close() {
+	d.State = DoorClosed
+	d.CurrentFrame = 0
+	d.MenuOpen = false
+}
+
+// Update advances the opening/closing animation when enough time has passed.
+// Once the final frame is reached, State switches to DoorOpen (or back to
+// DoorClosed when closing). Returns true on the frame the door becomes open.
+func (d *Door) Update() bool {
+	if d.State != DoorOpening && d.State != DoorClosing {
+		return false
 	}
 
 	// Only move to the next frame if FrameDelay has elapsed
-	if time.Since(d.lastFrameTime) < d.FrameDelay {
-		return
+	if clock.Since(d.lastFrameTime) < d.FrameDelay {
+		return false
+	}
+	d.lastFrameTime = clock.Now()
+
+	if d.State == DoorClosing {
+		d.CurrentFrame--
+		if d.CurrentFrame <= 0 {
+			d.CurrentFrame = 0
+			d.State = DoorClosed
+		}
+		return false
 	}
 
-	d.lastFrameTime = time.Now()
 	d.CurrentFrame++
 	if d.CurrentFrame >= len(d.Frames) {
 		// Reached last frame ⇒ fully open
 		d.CurrentFrame = len(d.Frames) - 1
 		d.State = DoorOpen
+		return true
 	}
+	return false
 }
 
 // Draw renders whichever frame is appropriate:
 //   - closed: always draw Frames[0]
-//   - opening: draw Frames[CurrentFrame]
+//   - opening/closing: draw Frames[CurrentFrame]
 //   - open: draw Frames[last index]
 func (d *Door) Draw() {
 	var tex rl.Texture2D
 	switch d.State {
 	case DoorClosed:
 		tex = d.Frames[0]
-	case DoorOpening, DoorOpen:
+	case DoorOpening, DoorOpen, DoorClosing:
 		tex = d.Frames[d.CurrentFrame]
 	}
 
@@ -167,9 +224,16 @@ func (d *Door) CheckCollision(px, py, pw, ph float32) bool {
 	return rl.CheckCollisionRecs(doorRect, playerRect)
 }
 
+// PlayerNear reports whether a player centred on playerPos is close enough to
+// use the door.
+func (d *Door) PlayerNear(playerPos rl.Vector2, playerWidth, playerHeight float32) bool {
+	const reach = 30 // a little slack either side of the door
+	return d.CheckCollision(playerPos.X-playerWidth/2-reach, playerPos.Y-playerHeight/2, playerWidth+2*reach, playerHeight)
+}
+
 // HandleMouse handles mouse interactions with the door
 func (d *Door) HandleMouse(playerPos rl.Vector2, playerWidth, playerHeight float32, camera rl.Camera2D) {
-	mousePos := rl.GetMousePosition()
+	mousePos := input.Mouse()
 	mx, my := mousePos.X, mousePos.Y
 
 	// Convert mouse position from screen coordinates to world coordinates
@@ -182,22 +246,22 @@ func (d *Door) HandleMouse(playerPos rl.Vector2, playerWidth, playerHeight float
 		worldMouseY >= d.Position.Y && worldMouseY <= d.Position.Y+d.Height
 
 	// Debug output
-	if rl.IsMouseButtonPressed(rl.MouseRightButton) {
+	if input.Pressed(input.RightClick) {
 		fmt.Printf("Door %s: State=%d, MouseOver=%v, PlayerNear=%v, MousePos=(%.1f,%.1f), WorldPos=(%.1f,%.1f), DoorPos=(%.1f,%.1f)\n",
-			d.ID, d.State, mouseOverDoor, d.CheckCollision(playerPos.X, playerPos.Y, playerWidth, playerHeight),
+			d.ID, d.State, mouseOverDoor, d.PlayerNear(playerPos, playerWidth, playerHeight),
 			mx, my, worldMouseX, worldMouseY, d.Position.X, d.Position.Y)
 	}
 
 	// If the context menu is open, handle clicks on it first
-	if d.MenuOpen && rl.IsMouseButtonReleased(rl.MouseLeftButton) {
+	if d.MenuOpen && input.Released(input.Click) {
 		d.handleMenuClick(mx, my)
 		return
 	}
 
 	// If right-click on door and no menu open, open context menu
-	if rl.IsMouseButtonPressed(rl.MouseRightButton) && !d.MenuOpen && mouseOverDoor {
-		// Only show menu if door is open (temporarily removed player proximity check for testing)
-		if d.State == DoorOpen {
+	if input.Pressed(input.RightClick) && !d.MenuOpen && mouseOverDoor {
+		// Only show menu if door is open and the player is standing at it
+		if d.State == DoorOpen && d.PlayerNear(playerPos, playerWidth, playerHeight) {
 			d.MenuOpen = true
 			d.MenuPosition = rl.NewVector2(mx, my)
 			fmt.Printf("Opening context menu for door %s\n", d.ID)
@@ -205,7 +269,7 @@ func (d *Door) HandleMouse(playerPos rl.Vector2, playerWidth, playerHeight float
 	}
 
 	// Close menu if clicking elsewhere
-	if d.MenuOpen && rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+	if d.MenuOpen && input.Pressed(input.Click) {
 		// Check if click is outside the menu
 		const menuItemWidth = 80
 		const menuItemHeight = 20
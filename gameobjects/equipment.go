@@ -0,0 +1,300 @@
+package gameobjects
+
+import (
+	"fmt"
+	"log"
+	"platformer-game/database"
+	"platformer-game/input"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// EquipSlot is where a piece of gear is worn.
+type EquipSlot int
+
+const (
+	SlotHead EquipSlot = iota
+	SlotBody
+	SlotAccessory
+	NumEquipSlots
+)
+
+func (s EquipSlot) String() string {
+	switch s {
+	case SlotHead:
+		return "Head"
+	case SlotBody:
+		return "Body"
+	default:
+		return "Accessory"
+	}
+}
+
+// Stats are the modifiers worn gear adds to the player.
+type Stats struct {
+	Armor       float64 // fraction of incoming damage absorbed
+	MaxHealth   float64 // added to the base maximum health
+	MoveSpeed   float32 // fraction added to walking and running speed
+	ReloadSpeed float32 // fraction added to reload speed
+}
+
+func (s Stats) add(o Stats) Stats {
+	return Stats{
+		Armor:       s.Armor + o.Armor,
+		MaxHealth:   s.MaxHealth + o.MaxHealth,
+		MoveSpeed:   s.MoveSpeed + o.MoveSpeed,
+		ReloadSpeed: s.ReloadSpeed + o.ReloadSpeed,
+	}
+}
+
+// Gear describes an item that can be worn.
+type Gear struct {
+	Slot          EquipSlot
+	Stats         Stats
+	MaxDurability int // hits it can take before it breaks
+}
+
+// GearDefs maps item names to their gear description; filled in by the game
+// at start-up.
+var GearDefs = map[string]Gear{}
+
+const (
+	baseMaxHealth = 100
+	maxArmor      = 0.75 // armor never makes the player untouchable
+)
+
+// Equipment is what the player is wearing, shown under the inventory grid.
+type Equipment struct {
+	Slots  [NumEquipSlots]Item
+	Origin rl.Vector2 // screen position of the head slot
+}
+
+func NewEquipment() Equipment {
+	var e Equipment
+	for s := range e.Slots {
+		e.Slots[s] = Item{Type: Other}
+	}
+	return e
+}
+
+func (e *Equipment) slotRect(s EquipSlot) rl.Rectangle {
+	return rl.NewRectangle(e.Origin.X+float32(s)*60, e.Origin.Y, 50, 50)
+}
+
+// Stats sums the modifiers of everything worn.
+func (e *Equipment) Stats() Stats {
+	var total Stats
+	for _, it := range e.Slots {
+		if it.Type == GearType {
+			total = total.add(GearDefs[it.Name].Stats)
+		}
+	}
+	if total.Armor > maxArmor {
+		total.Armor = maxArmor
+	}
+	return total
+}
+
+// Equip wears the gear in inventory slot i. Whatever was worn in that
+// equipment slot goes back into the inventory slot.
+func (p *Player) Equip(i int) {
+	it := p.Inventory.Slots[i]
+	g, ok := GearDefs[it.Name]
+	if !ok {
+		log.Printf("%s can't be worn\n", it.Name)
+		return
+	}
+	p.Inventory.Slots[i] = p.Equipment.Slots[g.Slot]
+	if p.Inventory.Slots[i].Type != GearType {
+		p.Inventory.Slots[i] = Item{Type: Other}
+	}
+	p.Equipment.Slots[g.Slot] = it
+	log.Printf("Equipped %s (%s)\n", it.Name, g.Slot)
+	p.applyStats()
+	p.Inventory.SaveToDB()
+	p.SaveEquipmentToDB()
+}
+
+// Unequip moves the gear worn in s into the first free inventory slot.
+// Returns false if nothing is worn there or the inventory is full.
+func (p *Player) Unequip(s EquipSlot) bool {
+	it := p.Equipment.Slots[s]
+	if it.Type != GearType {
+		return false
+	}
+	if !p.Inventory.AddItem(it) {
+		log.Println("Inventory full! Can't take off", it.Name)
+		return false
+	}
+	p.Equipment.Slots[s] = Item{Type: Other}
+	log.Printf("Took off %s\n", it.Name)
+	p.applyStats()
+	p.Inventory.SaveToDB()
+	p.SaveEquipmentToDB()
+	return true
+}
+
+// applyStats re-derives the stats that are stored on the player.
+func (p *Player) applyStats() {
+	p.MaxHealth = baseMaxHealth + p.Equipment.Stats().MaxHealth
+	if p.Health > p.MaxHealth {
+		p.Health = p.MaxHealth
+	}
+}
+
+// moveFactor scales horizontal speed by the worn gear.
+func (p *Player) moveFactor() float32 {
+	return 1 + p.Equipment.Stats().MoveSpeed
+}
+
+// reloadFactor scales reload speed by the worn gear.
+func (p *Player) reloadFactor() float32 {
+	return 1 + p.Equipment.Stats().ReloadSpeed
+}
+
+// TakeDamage hurts the player, less whatever the armor absorbs. Every worn
+// piece of gear loses a point of durability and breaks when it runs out.
+func (p *Player) TakeDamage(dmg float64) {
+	if p.Health <= 0 {
+		return
+	}
+	p.Health -= dmg * (1 - p.Equipment.Stats().Armor)
+	AddTrauma(TraumaHit)
+	if p.Health <= 0 {
+		p.Health = 0
+		if p.IsGameOver() {
+			log.Println("Game Over: Player Health is 0")
+		}
+	}
+
+	worn := false
+	for s := range p.Equipment.Slots {
+		it := &p.Equipment.Slots[s]
+		if it.Type != GearType {
+			continue
+		}
+		worn = true
+		it.Durability--
+		if it.Durability <= 0 {
+			log.Printf("Your %s broke!\n", it.Name)
+			*it = Item{Type: Other}
+		}
+	}
+	if worn {
+		p.applyStats()
+		p.SaveEquipmentToDB()
+	}
+}
+
+// layoutEquipment keeps the equipment slots just under the inventory window.
+func (p *Player) layoutEquipment() {
+	b := p.Inventory.Bounds()
+	p.Equipment.Origin = rl.NewVector2(b.X, b.Y+b.Height+30)
+}
+
+// HandleEquipmentMouse takes off the gear under a left-click.
+func (p *Player) HandleEquipmentMouse() {
+	p.layoutEquipment()
+	if !input.Pressed(input.Click) || p.Inventory.Dragging || p.Inventory.MenuOpen {
+		return
+	}
+	m := input.Mouse()
+	for s := SlotHead; s < NumEquipSlots; s++ {
+		if rl.CheckCollisionPointRec(m, p.Equipment.slotRect(s)) {
+			p.Unequip(s)
+			return
+		}
+	}
+}
+
+// DrawEquipment draws the equipment slots and the stats they add (screen space).
+func (p *Player) DrawEquipment() {
+	p.layoutEquipment()
+	e := &p.Equipment
+	for s := SlotHead; s < NumEquipSlots; s++ {
+		r := e.slotRect(s)
+		x, y, w, h := int32(r.X), int32(r.Y), int32(r.Width), int32(r.Height)
+		rl.DrawText(s.String(), x, y-14, 12, rl.RayWhite)
+		rl.DrawRectangle(x, y, w, h, rl.DarkGray)
+		rl.DrawRectangleLines(x, y, w, h, rl.LightGray)
+
+		it := e.Slots[s]
+		if it.Type != GearType || it.Image.ID == 0 {
+			continue
+		}
+		scale := float32(w) / max(float32(it.Image.Width), float32(it.Image.Height))
+		rl.DrawTextureEx(it.Image, rl.NewVector2(r.X, r.Y), 0, scale, rl.White)
+		drawDurability(it, x, y, w, h)
+	}
+
+	st := e.Stats()
+	sx := int32(e.Origin.X) + int32(NumEquipSlots)*60 + 10
+	sy := int32(e.Origin.Y)
+	rl.DrawText(fmt.Sprintf("Armor: %.0f%%", st.Armor*100), sx, sy, 12, rl.RayWhite)
+	rl.DrawText(fmt.Sprintf("Max health: %+.0f", st.MaxHealth), sx, sy+14, 12, rl.RayWhite)
+	rl.DrawText(fmt.Sprintf("Move speed: %+.0f%%", st.MoveSpeed*100), sx, sy+28, 12, rl.RayWhite)
+	rl.DrawText(fmt.Sprintf("Reload speed: %+.0f%%", st.ReloadSpeed*100), sx, sy+42, 12, rl.RayWhite)
+
+	m := input.Mouse()
+	for s := SlotHead; s < NumEquipSlots; s++ {
+		if it := e.Slots[s]; it.Type == GearType && rl.CheckCollisionPointRec(m, e.slotRect(s)) {
+			drawTooltip(tooltipLines(it), rl.NewVector2(m.X+14, m.Y+14))
+		}
+	}
+}
+
+// drawDurability draws a thin wear bar along the bottom of a slot holding gear.
+func drawDurability(it Item, x, y, w, h int32) {
+	g, ok := GearDefs[it.Name]
+	if it.Type != GearType || !ok || g.MaxDurability <= 0 {
+		return
+	}
+	frac := float32(it.Durability) / float32(g.MaxDurability)
+	col := rl.Green
+	if frac < 0.3 {
+		col = rl.Red
+	}
+	rl.DrawRectangle(x+2, y+h-5, w-4, 3, rl.Black)
+	rl.DrawRectangle(x+2, y+h-5, int32(float32(w-4)*frac), 3, col)
+}
+
+// SaveEquipmentToDB writes every equipment slot to the "equipment" table.
+func (p *Player) SaveEquipmentToDB() {
+	for s, it := range p.Equipment.Slots {
+		_, err := database.DB.Exec(`
+			INSERT OR REPLACE INTO equipment (slot, name, durability)
+			VALUES (?, ?, ?);`,
+			s, it.Name, it.Durability)
+		if err != nil {
+			log.Println("Failed to save equipment:", err)
+		}
+	}
+}
+
+// LoadEquipmentFromDB restores the worn gear and applies its stats.
+func (p *Player) LoadEquipmentFromDB(itemTextures map[string]rl.Texture2D) {
+	rows, err := database.DB.Query(`SELECT slot, name, durability FROM equipment`)
+	if err != nil {
+		log.Println("Failed to load equipment from database:", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var slot, durability int
+		var name string
+		if err := rows.Scan(&slot, &name, &durability); err != nil {
+			log.Println("Error scanning equipment row:", err)
+			continue
+		}
+		if slot < 0 || slot >= int(NumEquipSlots) || name == "" {
+			continue
+		}
+		if _, ok := GearDefs[name]; !ok {
+			log.Printf("Unknown gear %q in database, skipping\n", name)
+			continue
+		}
+		p.Equipment.Slots[slot] = Item{Type: GearType, Name: name, Image: itemTextures[name], Durability: durability}
+	}
+	p.applyStats()
+}
@@ -2,15 +2,16 @@ package gameobjects
 
 import (
 	rl "github.com/gen2brain/raylib-go/raylib"
+	"platformer-game/clock"
 	"time"
 )
 
 type Explosion struct {
-	Position    rl.Vector2
-	Texture     rl.Texture2D
-	IsActive    bool
-	StartTime   time.Time
-	Duration    time.Duration
+	Position  rl.Vector2
+	Texture   rl.Texture2D
+	IsActive  bool
+	StartTime time.Time
+	Duration  time.Duration
 }
 
 // Initialize a new explosion
@@ -19,14 +20,14 @@ func NewExplosion(x, y float32, texture rl.Texture2D) Explosion {
 		Position:  rl.NewVector2(x, y),
 		Texture:   texture,
 		IsActive:  true,
-		StartTime: time.Now(),
+		StartTime: clock.Now(),
 		Duration:  500 * time.Millisecond, // Explosion lasts for 0.5 seconds
 	}
 }
 
 // Update the explosion status based on elapsed time
 func (e *Explosion) Update() {
-	if time.Since(e.StartTime) > e.Duration {
+	if clock.Since(e.StartTime) > e.Duration {
 		e.IsActive = false // Deactivate the explosion after the duration
 	}
 }
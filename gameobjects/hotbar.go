@@ -0,0 +1,123 @@
+package gameobjects
+
+import (
+	"fmt"
+	"log"
+	"platformer-game/input"
+	"platformer-game/ui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const hotbarSize = 9 // keys 1-9
+
+// Hotbar binds the number keys to inventory slots.
+type Hotbar struct {
+	Slots    [hotbarSize]int // inventory slot index for each key, -1 = unbound
+	Selected int
+	OnBind   func() // if set, called after a key is bound to a new slot
+}
+
+// NewHotbar binds keys 1-9 to the first nine inventory slots.
+func NewHotbar() Hotbar {
+	var h Hotbar
+	for k := range h.Slots {
+		h.Slots[k] = k
+	}
+	return h
+}
+
+// UpdateHotbar handles the quick slots. With the inventory open, pressing a
+// number over a slot binds that slot to the key. Otherwise a number key
+// selects its slot and uses it straight away (weapons are held, consumables
+// used, gear put on), and the mouse wheel cycles the selection.
+func (p *Player) UpdateHotbar() {
+	inv := &p.Inventory
+	h := &p.Hotbar
+
+	if inv.IsOpen {
+		m := input.Mouse()
+		i := inv.SlotAt(m.X, m.Y)
+		if i < 0 {
+			return
+		}
+		for k := range h.Slots {
+			if input.Pressed(input.Slot(k)) {
+				h.Slots[k] = i
+				log.Printf("Bound slot %d to key %d\n", i, k+1)
+				if h.OnBind != nil {
+					h.OnBind()
+				}
+			}
+		}
+		return
+	}
+
+	for k := range h.Slots {
+		if input.Pressed(input.Slot(k)) {
+			p.selectHotbar(k, true)
+		}
+	}
+	if wheel := input.Wheel(); wheel != 0 {
+		step := 1
+		if wheel > 0 {
+			step = -1
+		}
+		p.selectHotbar((h.Selected+step+hotbarSize)%hotbarSize, false)
+	}
+}
+
+// selectHotbar makes key k the selected quick slot. A weapon there is
+// equipped as from the inventory menu, trading places with the one in hand;
+// anything else is used if use is true.
+func (p *Player) selectHotbar(k int, use bool) {
+	h := &p.Hotbar
+	h.Selected = k
+	i := h.Slots[k]
+	if i < 0 || i >= len(p.Inventory.Slots) {
+		return
+	}
+	switch it := p.Inventory.Slots[i]; it.Type {
+	case Other:
+	case Weapon:
+		log.Printf("Holding %s\n", it.Name)
+		p.EquipItem(i)
+	default:
+		if use {
+			p.EquipItem(i)
+		}
+	}
+}
+
+// DrawHotbar draws the quick slots along the bottom of a screenW×screenH screen.
+func (p *Player) DrawHotbar(screenW, screenH int32) {
+	const (
+		size = 40
+		gap  = 4
+	)
+	h := &p.Hotbar
+	x, y := ui.Place(ui.BottomCenter, hotbarSize*(size+gap)-gap, size, 0, 8, screenW, screenH)
+
+	for k, i := range h.Slots {
+		bx := x + int32(k)*(size+gap)
+		rl.DrawRectangle(bx, y, size, size, rl.Fade(rl.Black, 0.6))
+		border := rl.Gray
+		if k == h.Selected {
+			border = rl.Yellow
+		}
+		rl.DrawRectangleLines(bx, y, size, size, border)
+
+		if i >= 0 && i < len(p.Inventory.Slots) {
+			if it := p.Inventory.Slots[i]; it.Type != Other && it.Image.ID != 0 {
+				scale := float32(size-6) / max(float32(it.Image.Width), float32(it.Image.Height))
+				rl.DrawTextureEx(it.Image, rl.NewVector2(float32(bx+3), float32(y+3)), 0, scale, rl.White)
+				drawDurability(it, bx, y, size, size)
+				if n := it.Quantity(); n > 1 {
+					label := fmt.Sprint(n)
+					rl.DrawText(label, bx+size-rl.MeasureText(label, 12)-2, y+size-13, 12, rl.White)
+				}
+			}
+		}
+		rl.DrawText(fmt.Sprint(k+1), bx+3, y+2, 10, rl.LightGray)
+	}
+}
@@ -1,9 +1,11 @@
 package gameobjects
 
 import (
+	"fmt"
 	rl "github.com/gen2brain/raylib-go/raylib"
 	"log"
 	"platformer-game/database"
+	"platformer-game/input"
 )
 
 type ItemType int
@@ -13,12 +15,67 @@ const (
 	HealthPack
 	KeyType // <-- new
 	Other
+	AmmoType // box of ammo; after Other so saved type numbers don't shift
+	GrenadeType
+	Material // crafting ingredient; can't be used on its own
+	GearType // worn in an equipment slot, see GearDefs
 )
 
 type Item struct {
-	Type  ItemType
-	Name  string
-	Image rl.Texture2D
+	Type       ItemType
+	Name       string
+	Image      rl.Texture2D
+	Durability int // gear only: hits left before it breaks
+	Count      int // how many share the slot; 0 counts as one
+}
+
+// maxStack is how many of one stackable item fit in a slot.
+const maxStack = 10
+
+// ItemDescriptions maps item names to the text shown in their tooltip;
+// filled in by the game at start-up.
+var ItemDescriptions = map[string]string{}
+
+// Stackable reports whether several of this item can share a slot.
+func (it Item) Stackable() bool {
+	switch it.Type {
+	case HealthPack, KeyType, AmmoType, GrenadeType, Material:
+		return true
+	}
+	return false
+}
+
+// Quantity is how many items the slot holds.
+func (it Item) Quantity() int {
+	if it.Type == Other {
+		return 0
+	}
+	if it.Count < 1 {
+		return 1
+	}
+	return it.Count
+}
+
+// merge moves as much of src onto the stack dst as fits. Returns whatever is
+// left of src (an empty item if it all fit).
+func merge(dst *Item, src Item) Item {
+	if dst.Type == Other || src.Type == Other || dst.Name != src.Name || !dst.Stackable() {
+		return src
+	}
+	room := maxStack - dst.Quantity()
+	n := src.Quantity()
+	if n > room {
+		n = room
+	}
+	if n <= 0 {
+		return src
+	}
+	dst.Count = dst.Quantity() + n
+	if left := src.Quantity() - n; left > 0 {
+		src.Count = left
+		return src
+	}
+	return Item{Type: Other}
 }
 
 type Inventory struct {
@@ -39,14 +96,34 @@ type Inventory struct {
 	MenuSlot     int        // which slot index the menu belongs to
 	MenuPosition rl.Vector2 // where to draw the menu (usually at mouse pos)
 
+	Origin rl.Vector2 // screen position of the top-left slot
+	Cols   int        // slots per row
+	OnSave func()     // if set, called instead of SaveToDB (e.g. for containers)
+
+	// TooltipExtra, if set, adds lines to the tooltip of the item in slot i
+	// (e.g. shop prices).
+	TooltipExtra func(it Item, i int) []string
+
+	// Window dragging/resizing (see HandleWindow)
+	moving   bool
+	resizing bool
+	grab     rl.Vector2 // mouse offset from Origin while moving
+}
+
+// cols is the number of slots per row.
+func (inv *Inventory) cols() int {
+	if inv.Cols < 1 {
+		return 5
+	}
+	return inv.Cols
 }
 
 // Helper: for slot index i, return its on‐screen x, y, width and height.
 func (inv *Inventory) slotRect(i int) (x, y, w, h int32) {
-	invX, invY := 100, 100 // same origin as your DrawInventory
+	invX, invY := int(inv.Origin.X), int(inv.Origin.Y)
 	slotSize := 50
 	padding := 10
-	cols := 5
+	cols := inv.cols()
 
 	row := i / cols
 	col := i % cols
@@ -59,17 +136,17 @@ func (inv *Inventory) slotRect(i int) (x, y, w, h int32) {
 }
 
 func (inv *Inventory) HandleMouse() {
-	mousePos := rl.GetMousePosition()
+	mousePos := input.Mouse()
 	mx, my := mousePos.X, mousePos.Y
 
 	// ─── 1) If the context menu is open, handle clicks on it first ───
-	if inv.MenuOpen && rl.IsMouseButtonReleased(rl.MouseLeftButton) {
+	if inv.MenuOpen && input.Released(input.Click) {
 		inv.handleMenuClick(mx, my)
 		return
 	}
 
 	// ─── 2) If right‐click on a non‐empty slot and no menu/drag in progress, open context menu ───
-	if rl.IsMouseButtonPressed(rl.MouseRightButton) && !inv.MenuOpen && !inv.Dragging {
+	if input.Pressed(input.RightClick) && !inv.MenuOpen && !inv.Dragging {
 		for i := 0; i < inv.MaxSlots; i++ {
 			x, y, w, h := inv.slotRect(i)
 			if mx >= float32(x) && mx <= float32(x+w) &&
@@ -86,7 +163,7 @@ func (inv *Inventory) HandleMouse() {
 	}
 
 	// ─── 3) If left‐click to pick up and no drag/menu active, begin dragging ───
-	if rl.IsMouseButtonPressed(rl.MouseLeftButton) && !inv.Dragging && !inv.MenuOpen {
+	if input.Pressed(input.Click) && !inv.Dragging && !inv.MenuOpen {
 		for i := 0; i < inv.MaxSlots; i++ {
 			x, y, w, h := inv.slotRect(i)
 			if mx >= float32(x) && mx <= float32(x+w) &&
@@ -104,7 +181,7 @@ func (inv *Inventory) HandleMouse() {
 	}
 
 	// ─── 4) If left‐button released while dragging, attempt to drop ‒ then clear drag state ───
-	if rl.IsMouseButtonReleased(rl.MouseLeftButton) && inv.Dragging {
+	if input.Released(input.Click) && inv.Dragging {
 		dropped := false
 
 		for j := 0; j < inv.MaxSlots; j++ {
@@ -115,6 +192,10 @@ func (inv *Inventory) HandleMouse() {
 				if inv.Slots[j].Type == Other {
 					inv.Slots[j] = inv.DraggedItem
 					dropped = true
+				} else if inv.Slots[j].Name == inv.DraggedItem.Name && inv.Slots[j].Stackable() {
+					// Stack onto the same item; whatever doesn't fit goes back
+					inv.Slots[inv.DraggedIndex] = merge(&inv.Slots[j], inv.DraggedItem)
+					dropped = true
 				} else {
 					inv.Slots[inv.DraggedIndex], inv.Slots[j] = inv.Slots[j], inv.DraggedItem
 					dropped = true
@@ -132,7 +213,7 @@ func (inv *Inventory) HandleMouse() {
 		inv.DraggedIndex = -1
 
 		// Auto-save entire inventory now that we've moved items:
-		inv.SaveToDB()
+		inv.save()
 	}
 }
 
@@ -180,7 +261,7 @@ func (inv *Inventory) handleMenuClick(mx, my float32) {
 		my >= equipRect.Y && my <= equipRect.Y+equipRect.Height {
 
 		// Only run EquipItem if it’s a valid type
-		if slotItem.Type == Weapon || slotItem.Type == HealthPack || slotItem.Type == KeyType {
+		if slotItem.Type == Weapon || slotItem.Type == HealthPack || slotItem.Type == KeyType || slotItem.Type == AmmoType || slotItem.Type == GrenadeType || slotItem.Type == GearType {
 			PlayerInstance.EquipItem(slotIndex)
 		}
 		clicked = true
@@ -196,7 +277,7 @@ func (inv *Inventory) handleMenuClick(mx, my float32) {
 		// Log it or spawn a world item here:
 		log.Printf("Dropped item %q from slot %d\n", droppedItem.Name, slotIndex)
 		inv.deleteSlotFromDB(slotIndex)
-		inv.SaveToDB()
+		inv.save()
 		clicked = true
 	}
 
@@ -207,7 +288,7 @@ func (inv *Inventory) handleMenuClick(mx, my float32) {
 
 		inv.Slots[slotIndex] = Item{Type: Other}
 		inv.deleteSlotFromDB(slotIndex)
-		inv.SaveToDB()
+		inv.save()
 		clicked = true
 	}
 
@@ -223,11 +304,23 @@ func NewInventory(maxSlots int) Inventory {
 	return Inventory{
 		Slots:    slots,
 		MaxSlots: maxSlots,
+		Origin:   rl.NewVector2(100, 100),
+		Cols:     5,
 	}
 }
 
-// Method to add an item to the inventory
+// Method to add an item to the inventory; stackable items go onto an
+// existing stack first if the whole lot fits
 func (inv *Inventory) AddItem(item Item) bool {
+	if item.Stackable() {
+		for i := range inv.Slots {
+			s := inv.Slots[i]
+			if s.Name == item.Name && s.Quantity()+item.Quantity() <= maxStack {
+				merge(&inv.Slots[i], item)
+				return true
+			}
+		}
+	}
 	for i := 0; i < inv.MaxSlots; i++ {
 		if inv.Slots[i].Type == Other {
 			inv.Slots[i] = item // Place item in the empty slot
@@ -237,6 +330,140 @@ func (inv *Inventory) AddItem(item Item) bool {
 	return false // Return false if inventory is full
 }
 
+// save persists the inventory wherever it belongs.
+func (inv *Inventory) save() {
+	if inv.OnSave != nil {
+		inv.OnSave()
+		return
+	}
+	inv.SaveToDB()
+}
+
+// SlotAt returns the slot index under the screen point (mx, my), or -1.
+func (inv *Inventory) SlotAt(mx, my float32) int {
+	for i := 0; i < inv.MaxSlots; i++ {
+		x, y, w, h := inv.slotRect(i)
+		if mx >= float32(x) && mx <= float32(x+w) &&
+			my >= float32(y) && my <= float32(y+h) {
+			return i
+		}
+	}
+	return -1
+}
+
+// HandleTransfer is HandleMouse for two inventories shown side by side (e.g.
+// the player's and an open chest): items can be dragged within either grid or
+// from one to the other. There is no context menu in this mode.
+func HandleTransfer(a, b *Inventory) {
+	b.Beside(a)
+	mousePos := input.Mouse()
+	mx, my := mousePos.X, mousePos.Y
+	invs := []*Inventory{a, b}
+
+	// Pick up
+	if input.Pressed(input.Click) && !a.Dragging && !b.Dragging {
+		for _, inv := range invs {
+			if i := inv.SlotAt(mx, my); i >= 0 && inv.Slots[i].Type != Other {
+				inv.Dragging = true
+				inv.DraggedIndex = i
+				inv.DraggedItem = inv.Slots[i]
+				inv.Slots[i] = Item{Type: Other}
+				break
+			}
+		}
+	}
+
+	if !input.Released(input.Click) {
+		return
+	}
+
+	// Drop
+	for _, from := range invs {
+		if !from.Dragging {
+			continue
+		}
+		dropped := false
+		for _, to := range invs {
+			j := to.SlotAt(mx, my)
+			if j < 0 {
+				continue
+			}
+			// Stack onto the same item, otherwise whatever was in the target
+			// slot goes back where the dragged item came from
+			if to.Slots[j].Name == from.DraggedItem.Name && to.Slots[j].Stackable() {
+				from.Slots[from.DraggedIndex] = merge(&to.Slots[j], from.DraggedItem)
+			} else {
+				from.Slots[from.DraggedIndex] = to.Slots[j]
+				to.Slots[j] = from.DraggedItem
+			}
+			dropped = true
+			if to != from {
+				to.save()
+			}
+			break
+		}
+		if !dropped {
+			from.Slots[from.DraggedIndex] = from.DraggedItem
+		}
+
+		from.Dragging = false
+		from.DraggedItem = Item{Type: Other}
+		from.DraggedIndex = -1
+		from.save()
+	}
+}
+
+// HasItem reports whether any slot holds an item called name.
+func (inv *Inventory) HasItem(name string) bool {
+	for _, it := range inv.Slots {
+		if it.Type != Other && it.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CountItem returns how many items called name the inventory holds.
+func (inv *Inventory) CountItem(name string) int {
+	n := 0
+	for _, it := range inv.Slots {
+		if it.Name == name {
+			n += it.Quantity()
+		}
+	}
+	return n
+}
+
+// RemoveItem takes one item called name (from the first slot holding it) and
+// saves the inventory. Returns false if there was no such item.
+func (inv *Inventory) RemoveItem(name string) bool {
+	for i, it := range inv.Slots {
+		if it.Type != Other && it.Name == name {
+			inv.RemoveAt(i)
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveAt takes one item from slot i and saves the inventory.
+func (inv *Inventory) RemoveAt(i int) {
+	inv.takeOne(i)
+	if inv.OnSave == nil && inv.Slots[i].Type == Other {
+		inv.deleteSlotFromDB(i)
+	}
+	inv.save()
+}
+
+// takeOne uses up one item from slot i, emptying the slot with the last one.
+func (inv *Inventory) takeOne(i int) {
+	if inv.Slots[i].Quantity() > 1 {
+		inv.Slots[i].Count = inv.Slots[i].Quantity() - 1
+		return
+	}
+	inv.Slots[i] = Item{Type: Other}
+}
+
 func (inv *Inventory) deleteSlotFromDB(slotIndex int) {
 	// Adjust the table/column names to match your schema
 	_, err := database.DB.Exec(`DELETE FROM inventory WHERE slot = ?`, slotIndex)
@@ -245,25 +472,29 @@ func (inv *Inventory) deleteSlotFromDB(slotIndex int) {
 	}
 }
 
-func (inv *Inventory) UpdateSelection() {
-	slotsPerRow := 5 // Number of slots per row
-	if rl.IsKeyPressed(rl.KeyRight) {
+// UpdateSelection moves the selected slot with the arrow keys or the
+// gamepad's d-pad. Returns true if the selection moved.
+func (inv *Inventory) UpdateSelection() bool {
+	slotsPerRow := inv.cols() // Number of slots per row
+	prev := inv.SelectedSlot
+	if input.Pressed(input.MenuRight) {
 		inv.SelectedSlot = (inv.SelectedSlot + 1) % inv.MaxSlots
 	}
-	if rl.IsKeyPressed(rl.KeyLeft) {
+	if input.Pressed(input.MenuLeft) {
 		inv.SelectedSlot = (inv.SelectedSlot - 1 + inv.MaxSlots) % inv.MaxSlots
 	}
-	if rl.IsKeyPressed(rl.KeyDown) {
+	if input.Pressed(input.MenuDown) {
 		inv.SelectedSlot = (inv.SelectedSlot + slotsPerRow) % inv.MaxSlots
 	}
-	if rl.IsKeyPressed(rl.KeyUp) {
+	if input.Pressed(input.MenuUp) {
 		inv.SelectedSlot = (inv.SelectedSlot - slotsPerRow + inv.MaxSlots) % inv.MaxSlots
 	}
+	return inv.SelectedSlot != prev
 }
 
 func (inv *Inventory) LoadFromDB(itemTextures map[string]rl.Texture2D) {
 	db := database.DB
-	rows, err := db.Query(`SELECT slot, type, name FROM inventory`)
+	rows, err := db.Query(`SELECT slot, type, name, durability, count FROM inventory`)
 	if err != nil {
 		log.Println("Failed to load inventory from database:", err)
 		return
@@ -274,8 +505,9 @@ func (inv *Inventory) LoadFromDB(itemTextures map[string]rl.Texture2D) {
 		var slot int
 		var itemType ItemType
 		var name string
+		var durability, count int
 
-		if err := rows.Scan(&slot, &itemType, &name); err != nil {
+		if err := rows.Scan(&slot, &itemType, &name, &durability, &count); err != nil {
 			log.Println("Error scanning inventory row:", err)
 			continue
 		}
@@ -294,9 +526,11 @@ func (inv *Inventory) LoadFromDB(itemTextures map[string]rl.Texture2D) {
 
 		// ✅ Assign item to slot only if texture is valid
 		inv.Slots[slot] = Item{
-			Type:  itemType,
-			Name:  name,
-			Image: texture,
+			Type:       itemType,
+			Name:       name,
+			Image:      texture,
+			Durability: durability,
+			Count:      count,
 		}
 	}
 }
@@ -335,12 +569,17 @@ func (inv *Inventory) DrawInventory() {
 			rl.DrawTextureEx(item.Image,
 				rl.Vector2{X: float32(drawX), Y: float32(drawY)},
 				0, scale, rl.White)
+			drawDurability(item, x, y, w, h)
+			if n := item.Quantity(); n > 1 {
+				label := fmt.Sprint(n)
+				rl.DrawText(label, x+w-rl.MeasureText(label, 14)-3, y+h-17, 14, rl.White)
+			}
 		}
 	}
 
 	// 2) If dragging, draw the dragged item at the mouse (centered)
 	if inv.Dragging && inv.DraggedItem.Type != Other && inv.DraggedItem.Image.ID != 0 {
-		mpos := rl.GetMousePosition()
+		mpos := input.Mouse()
 		tex := inv.DraggedItem.Image
 
 		textureWidth := float32(tex.Width)
@@ -379,6 +618,12 @@ func (inv *Inventory) DrawInventory() {
 			topLabel = "Use"
 		case KeyType:
 			topLabel = "Use" // using a door key
+		case AmmoType:
+			topLabel = "Use"
+		case GrenadeType:
+			topLabel = "Throw"
+		case GearType:
+			topLabel = "Equip"
 		default:
 			topLabel = ""
 		}
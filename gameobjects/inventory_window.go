@@ -0,0 +1,262 @@
+package gameobjects
+
+import (
+	"fmt"
+	"platformer-game/input"
+	"platformer-game/rendering"
+	"sort"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Layout of the window around the slot grid
+const (
+	titleBarH  = 22
+	buttonW    = 44
+	handleSize = 10
+	minCols    = 3  // fewer and the grid runs off the bottom of the screen
+	panelGap   = 20 // between the window and a panel shown beside it
+)
+
+// Bounds is the screen rectangle covered by the slot grid.
+func (inv *Inventory) Bounds() rl.Rectangle {
+	cols := inv.cols()
+	if cols > inv.MaxSlots {
+		cols = inv.MaxSlots
+	}
+	x0, y0, _, _ := inv.slotRect(0)
+	x1, _, w, _ := inv.slotRect(cols - 1)
+	_, y1, _, h := inv.slotRect(inv.MaxSlots - 1)
+	return rl.NewRectangle(float32(x0), float32(y0), float32(x1+w-x0), float32(y1+h-y0))
+}
+
+// PanelOrigin returns the top-left corner for a panel w wide shown beside
+// the window (a container, a shop, the crafting panel): to the right of it,
+// or to the left if there isn't room on screen.
+func (inv *Inventory) PanelOrigin(w float32) rl.Vector2 {
+	b := inv.Bounds()
+	x := b.X + b.Width + handleSize + panelGap
+	if x+w > float32(rendering.ScreenWidth) {
+		x = max(b.X-panelGap-w, 0)
+	}
+	return rl.NewVector2(x, b.Y)
+}
+
+// Beside moves the grid next to other's window. Grids shown alongside the
+// inventory call it every frame, so they follow the window as it's moved or
+// resized.
+func (inv *Inventory) Beside(other *Inventory) {
+	inv.Origin = other.PanelOrigin(inv.Bounds().Width)
+}
+
+func (inv *Inventory) titleRect() rl.Rectangle {
+	b := inv.Bounds()
+	return rl.NewRectangle(b.X, b.Y-titleBarH-4, b.Width, titleBarH)
+}
+
+func (inv *Inventory) sortRect() rl.Rectangle {
+	t := inv.titleRect()
+	return rl.NewRectangle(t.X+t.Width-2*buttonW-4, t.Y+2, buttonW, t.Height-4)
+}
+
+func (inv *Inventory) stackRect() rl.Rectangle {
+	t := inv.titleRect()
+	return rl.NewRectangle(t.X+t.Width-buttonW-2, t.Y+2, buttonW, t.Height-4)
+}
+
+func (inv *Inventory) handleRect() rl.Rectangle {
+	b := inv.Bounds()
+	return rl.NewRectangle(b.X+b.Width, b.Y+b.Height, handleSize, handleSize)
+}
+
+// HandleWindow handles the window around the grid: drag the title bar to
+// move it, drag the corner handle to change the number of columns, and the
+// Sort/Stack buttons. Returns true while it has the mouse, so the grid
+// shouldn't handle it too.
+func (inv *Inventory) HandleWindow() bool {
+	m := input.Mouse()
+
+	if input.Pressed(input.Click) && !inv.Dragging && !inv.MenuOpen {
+		switch {
+		case rl.CheckCollisionPointRec(m, inv.sortRect()):
+			inv.Sort()
+			return true
+		case rl.CheckCollisionPointRec(m, inv.stackRect()):
+			inv.Stack()
+			return true
+		case rl.CheckCollisionPointRec(m, inv.handleRect()):
+			inv.resizing = true
+		case rl.CheckCollisionPointRec(m, inv.titleRect()):
+			inv.moving = true
+			inv.grab = rl.Vector2Subtract(m, inv.Origin)
+		}
+	}
+	if input.Released(input.Click) {
+		inv.moving = false
+		inv.resizing = false
+	}
+
+	if inv.moving {
+		b := inv.Bounds()
+		maxX := float32(rendering.ScreenWidth) - b.Width
+		maxY := float32(rendering.ScreenHeight) - b.Height
+		inv.Origin.X = rl.Clamp(m.X-inv.grab.X, 0, maxX)
+		inv.Origin.Y = rl.Clamp(m.Y-inv.grab.Y, titleBarH+4, maxY)
+	}
+	if inv.resizing {
+		cols := int((m.X-inv.Origin.X)/60 + 0.5)
+		if cols > inv.MaxSlots {
+			cols = inv.MaxSlots
+		}
+		if cols < minCols {
+			cols = minCols
+		}
+		inv.Cols = cols
+	}
+	return inv.moving || inv.resizing
+}
+
+// DrawWindow draws the title bar, the Sort/Stack buttons and the resize handle.
+func (inv *Inventory) DrawWindow(title string) {
+	t := inv.titleRect()
+	rl.DrawRectangleRec(t, rl.Fade(rl.Black, 0.7))
+	rl.DrawText(title, int32(t.X)+6, int32(t.Y)+5, 12, rl.RayWhite)
+	for _, btn := range []struct {
+		r     rl.Rectangle
+		label string
+	}{{inv.sortRect(), "Sort"}, {inv.stackRect(), "Stack"}} {
+		rl.DrawRectangleRec(btn.r, rl.DarkGray)
+		lw := rl.MeasureText(btn.label, 12)
+		rl.DrawText(btn.label, int32(btn.r.X)+(buttonW-lw)/2, int32(btn.r.Y)+4, 12, rl.White)
+	}
+	h := inv.handleRect()
+	rl.DrawTriangle(
+		rl.NewVector2(h.X+h.Width, h.Y),
+		rl.NewVector2(h.X, h.Y+h.Height),
+		rl.NewVector2(h.X+h.Width, h.Y+h.Height),
+		rl.LightGray,
+	)
+}
+
+// Sort packs the items to the front, grouped by type and then by name.
+func (inv *Inventory) Sort() {
+	var items []Item
+	for _, it := range inv.Slots {
+		if it.Type != Other {
+			items = append(items, it)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Type != items[j].Type {
+			return items[i].Type < items[j].Type
+		}
+		return items[i].Name < items[j].Name
+	})
+	for i := range inv.Slots {
+		if i < len(items) {
+			inv.Slots[i] = items[i]
+		} else {
+			inv.Slots[i] = Item{Type: Other}
+		}
+	}
+	inv.save()
+}
+
+// Stack merges stacks of the same item, filling the earlier slot first.
+func (inv *Inventory) Stack() {
+	for i := range inv.Slots {
+		for j := i + 1; j < len(inv.Slots); j++ {
+			if inv.Slots[j].Name == inv.Slots[i].Name && inv.Slots[i].Stackable() {
+				inv.Slots[j] = merge(&inv.Slots[i], inv.Slots[j])
+			}
+		}
+	}
+	inv.save()
+}
+
+// tooltipLines is the name, description and stats shown for an item.
+func tooltipLines(it Item) []string {
+	lines := []string{it.Name}
+	if n := it.Quantity(); n > 1 {
+		lines[0] = fmt.Sprintf("%s x%d", it.Name, n)
+	}
+	if d := ItemDescriptions[it.Name]; d != "" {
+		lines = append(lines, d)
+	}
+	if g, ok := GearDefs[it.Name]; ok {
+		lines = append(lines, g.Slot.String()+" slot")
+		st := g.Stats
+		if st.Armor != 0 {
+			lines = append(lines, fmt.Sprintf("Armor %+.0f%%", st.Armor*100))
+		}
+		if st.MaxHealth != 0 {
+			lines = append(lines, fmt.Sprintf("Max health %+.0f", st.MaxHealth))
+		}
+		if st.MoveSpeed != 0 {
+			lines = append(lines, fmt.Sprintf("Move speed %+.0f%%", st.MoveSpeed*100))
+		}
+		if st.ReloadSpeed != 0 {
+			lines = append(lines, fmt.Sprintf("Reload speed %+.0f%%", st.ReloadSpeed*100))
+		}
+		lines = append(lines, fmt.Sprintf("Durability %d/%d", it.Durability, g.MaxDurability))
+	}
+	return lines
+}
+
+// DrawTooltip describes the item under the mouse, or the selected slot when
+// keyboard is true (the player is navigating with keys or a gamepad).
+func (inv *Inventory) DrawTooltip(keyboard bool) {
+	if inv.Dragging || inv.MenuOpen {
+		return
+	}
+	m := input.Mouse()
+	i := inv.SlotAt(m.X, m.Y)
+	pos := rl.NewVector2(m.X+14, m.Y+14)
+	if keyboard {
+		i = inv.SelectedSlot
+		x, y, w, _ := inv.slotRect(i)
+		pos = rl.NewVector2(float32(x+w+4), float32(y))
+	}
+	if i < 0 || i >= len(inv.Slots) || inv.Slots[i].Type == Other {
+		return
+	}
+	lines := tooltipLines(inv.Slots[i])
+	if inv.TooltipExtra != nil {
+		lines = append(lines, inv.TooltipExtra(inv.Slots[i], i)...)
+	}
+	drawTooltip(lines, pos)
+}
+
+func drawTooltip(lines []string, pos rl.Vector2) {
+	const (
+		fontSize = 12
+		lineH    = 15
+		pad      = 6
+	)
+	var w int32
+	for _, l := range lines {
+		if lw := rl.MeasureText(l, fontSize); lw > w {
+			w = lw
+		}
+	}
+	w += 2 * pad
+	h := int32(len(lines)*lineH + 2*pad)
+	x, y := int32(pos.X), int32(pos.Y)
+	// Keep it on screen
+	if x+w > int32(rendering.ScreenWidth) {
+		x = int32(rendering.ScreenWidth) - w
+	}
+	if y+h > int32(rendering.ScreenHeight) {
+		y = int32(rendering.ScreenHeight) - h
+	}
+
+	rl.DrawRectangle(x, y, w, h, rl.Fade(rl.Black, 0.9))
+	rl.DrawRectangleLines(x, y, w, h, rl.Gray)
+	for i, l := range lines {
+		col := rl.LightGray
+		if i == 0 {
+			col = rl.Gold
+		}
+		rl.DrawText(l, x+pad, y+pad+int32(i*lineH), fontSize, col)
+	}
+}
@@ -9,6 +9,8 @@ type WorldItem struct {
 	Texture  rl.Texture2D
 	Type     ItemType // Referencing ItemType from Inventory
 	Name     string
+	Glow     rl.Color // drawn under the item when Glow.A > 0 (loot rarity)
+	Amount   int      // coins: how many the pickup is worth
 }
 
 func NewWorldItem(x, y float32, itemType ItemType, name string, texturePath string) WorldItem {
@@ -20,14 +22,42 @@ func NewWorldItem(x, y float32, itemType ItemType, name string, texturePath stri
 	}
 }
 
-func (item *WorldItem) Draw() {
-	scale := float32(1.0)
+// NewDroppedItem creates a WorldItem from an already-loaded texture, for loot
+// spawned at runtime so the same file isn't loaded over and over.
+func NewDroppedItem(x, y float32, itemType ItemType, name string, tex rl.Texture2D) *WorldItem {
+	return &WorldItem{
+		Position: rl.NewVector2(x, y),
+		Texture:  tex,
+		Type:     itemType,
+		Name:     name,
+	}
+}
+
+// scale is how much the item's texture is shrunk when drawn.
+func (item *WorldItem) scale() float32 {
 	switch item.Type {
 	case HealthPack:
-		scale = 0.5
+		return 0.5
 	case KeyType:
-		scale = 0.4
+		return 0.4
 		// you can add more custom scales here (e.g. Weapon = 0.8, etc.)
+	case AmmoType:
+		return 0.6
+	}
+	return 1
+}
+
+// SetOnFloor moves the item up or down so it rests on the floor at y.
+func (item *WorldItem) SetOnFloor(y float32) {
+	item.Position.Y = y - float32(item.Texture.Height)*item.scale()
+}
+
+func (item *WorldItem) Draw() {
+	scale := item.scale()
+	if item.Glow.A > 0 {
+		w := float32(item.Texture.Width) * scale
+		h := float32(item.Texture.Height) * scale
+		rl.DrawEllipse(int32(item.Position.X+w/2), int32(item.Position.Y+h), w/2+6, 5, rl.Fade(item.Glow, 0.6))
 	}
 	rl.DrawTextureEx(item.Texture,
 		rl.Vector2{X: item.Position.X, Y: item.Position.Y},
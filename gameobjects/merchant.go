@@ -0,0 +1,46 @@
+package gameobjects
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// Merchant is a shopkeeper standing in the world. Their stock lives in the
+// shop package; Shop is its ID.
+type Merchant struct {
+	ID       string
+	Name     string
+	Shop     string
+	Position rl.Vector2 // top-left, standing on the ground
+	Width    float32
+	Height   float32
+}
+
+func NewMerchant(id, name, shop string, x, groundY float32) *Merchant {
+	return &Merchant{
+		ID:       id,
+		Name:     name,
+		Shop:     shop,
+		Position: rl.NewVector2(x, groundY-90),
+		Width:    40,
+		Height:   90,
+	}
+}
+
+// PlayerNear reports whether a player centred on playerPos can trade.
+func (m *Merchant) PlayerNear(playerPos rl.Vector2, playerWidth, playerHeight float32) bool {
+	const reach = 40
+	r := rl.NewRectangle(m.Position.X-reach, m.Position.Y, m.Width+2*reach, m.Height)
+	p := rl.NewRectangle(playerPos.X-playerWidth/2, playerPos.Y-playerHeight/2, playerWidth, playerHeight)
+	return rl.CheckCollisionRecs(r, p)
+}
+
+// Draw draws the merchant as a simple figure with a stall sign (world space).
+func (m *Merchant) Draw() {
+	x, y := int32(m.Position.X), int32(m.Position.Y)
+	w, h := int32(m.Width), int32(m.Height)
+	head := w / 2
+
+	rl.DrawCircle(x+w/2, y+head/2, float32(head)/2, rl.Beige)
+	rl.DrawRectangle(x, y+head, w, h-head, rl.DarkPurple)
+	rl.DrawRectangle(x-10, y+h/2, w+20, 8, rl.Brown) // counter
+	tw := rl.MeasureText(m.Name, 12)
+	rl.DrawText(m.Name, x+(w-tw)/2, y-16, 12, rl.Gold)
+}
@@ -1,10 +1,16 @@
 package gameobjects
 
 import (
-	"math/rand"
+	"log"
+	"math"
+	"os"
 	"time"
 
+	"platformer-game/ai"
+	"platformer-game/clock"
+	"platformer-game/config"
 	"platformer-game/rendering"
+	"platformer-game/rng"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
@@ -20,6 +26,14 @@ const (
 	MouseSpecial
 )
 
+// How mice react to the world.
+const (
+	mouseFleeDistance  = 150  // scurry off when the (fully visible) player gets this close
+	mouseFleeSpeed     = 0.12 // per-frame speed while scurrying, a bit faster than the player walks
+	mouseFleeTime      = 1500 * time.Millisecond
+	NoiseCritterRadius = 200 // how far zombies hear a scurrying mouse
+)
+
 // Mouse represents an NPC with states, animations, and sounds.
 type Mouse struct {
 	Position        rl.Vector2
@@ -30,6 +44,7 @@ type Mouse struct {
 	FrameCounter    int
 	LastStateChange time.Time
 	NextStateChange time.Time // New field for fixed state duration
+	ScaredUntil     time.Time // while in the future the mouse is running away
 
 	IdleFrames    []rl.Texture2D
 	WalkFrames    []rl.Texture2D
@@ -53,13 +68,14 @@ func NewMouse(x, y float32) *Mouse {
 		State:           MouseIdle,
 		CurrentFrame:    0,
 		FrameCounter:    0,
-		LastStateChange: time.Now(),
+		LastStateChange: clock.Now(),
 		Width:           20, // set to the appropriate width
 		Height:          12, // set to the appropriate height
 	}
 
 	// Load the spritesheet for the mouse NPC.
-	spriteSheet := rendering.LoadSpriteSheet("assets/sprites/mousespritesheet1.png")
+	spriteSheet := loadMouseSheet()
+	defer spriteSheet.Unload()
 
 	// --- Load Idle Frames ---
 	// Using updated positions and sizes from CSS:
@@ -172,18 +188,39 @@ func NewMouse(x, y float32) *Mouse {
 	}
 
 	// --- Load Sounds for each state ---
-	m.IdleSound = rl.LoadSound("assets/sounds/mouse_idle.mp3")
-	m.WalkSound = rl.LoadSound("assets/sounds/mouse_walk.mp3")
-	m.JumpSound = rl.LoadSound("assets/sounds/mouse_jump.mp3")
-	m.AttackSound = rl.LoadSound("assets/sounds/mouse_attack.mp3")
-	m.SpecialSound = rl.LoadSound("assets/sounds/mouse_special.mp3")
+	m.IdleSound = rl.LoadSound(config.Asset("sounds/mouse_idle.mp3"))
+	m.WalkSound = rl.LoadSound(config.Asset("sounds/mouse_walk.mp3"))
+	m.JumpSound = rl.LoadSound(config.Asset("sounds/mouse_jump.mp3"))
+	m.AttackSound = rl.LoadSound(config.Asset("sounds/mouse_attack.mp3"))
+	m.SpecialSound = rl.LoadSound(config.Asset("sounds/mouse_special.mp3"))
 
 	return m
 }
 
+// Size of the mouse sprite sheet, or of the stand-in used without it.
+const mouseSheetWidth, mouseSheetHeight = 600, 380
+
+var mouseSheetWarned bool // missing sheet already logged
+
+// loadMouseSheet loads the mouse sprite sheet. Without it (the art isn't in
+// the assets yet) it logs once and returns a plain grey sheet of the same
+// size, so the frames cut from it draw mice as grey blocks.
+func loadMouseSheet() rendering.SpriteSheet {
+	path := config.Asset("sprites/mousespritesheet1.png")
+	if _, err := os.Stat(path); err == nil {
+		return rendering.LoadSpriteSheet(path)
+	}
+	if !mouseSheetWarned {
+		log.Println("Mouse sprite sheet missing, drawing placeholders:", path)
+		mouseSheetWarned = true
+	}
+	return rendering.SpriteSheet{Image: rl.GenImageColor(mouseSheetWidth, mouseSheetHeight, rl.Gray)}
+}
+
 // Update handles the mouse AI by switching states and updating animations.
-// For demonstration, it randomly changes state every 2-4 seconds.
-func (m *Mouse) Update(worldWidth, worldHeight float32) {
+// Left alone it randomly changes state every 2-4 seconds; a player walking up
+// to it or a gunshot/explosion within earshot sends it scurrying away.
+func (m *Mouse) Update(worldWidth, worldHeight float32, player ai.Target, noise []ai.Stimulus) {
 	// Debug print.
 	//fmt.Println("Mouse State:", m.State)
 	//fmt.Println("Mouse Position:", m.Position)
@@ -202,16 +239,33 @@ func (m *Mouse) Update(worldWidth, worldHeight float32) {
 		m.Speed.Y = 0
 	}
 
+	// --- Scared? ---
+	center := rl.NewVector2(m.Position.X+m.Width/2, m.Position.Y+m.Height/2)
+	dx := math.Abs(float64(player.Position.X - center.X))
+	dy := math.Abs(float64(player.Position.Y - center.Y))
+	if float32(dx) < mouseFleeDistance*player.Visibility && dy < mouseFleeDistance {
+		m.scare(player.Position.X)
+	}
+	for _, st := range noise {
+		if (st.Kind == ai.StimGunshot || st.Kind == ai.StimExplosion) && rl.Vector2Distance(center, st.Position) <= st.Radius {
+			m.scare(st.Position.X)
+		}
+	}
+	if clock.Now().Before(m.ScaredUntil) {
+		// Zombies hear the scurrying and come to look
+		EmitNoise(ai.StimCritter, center, NoiseCritterRadius)
+	}
+
 	// --- State Switching ---
-	if time.Now().After(m.NextStateChange) {
-		newState := MouseState(rand.Intn(5)) // Random state from 0 to 4.
+	if clock.Now().After(m.NextStateChange) && clock.Now().After(m.ScaredUntil) {
+		newState := MouseState(rng.Get(rng.AI).Intn(5)) // Random state from 0 to 4.
 		if newState != m.State {
 			m.State = newState
 			m.CurrentFrame = 0
 			m.FrameCounter = 0
-			m.LastStateChange = time.Now()
+			m.LastStateChange = clock.Now()
 			// Set the next state change time.
-			m.NextStateChange = time.Now().Add(time.Duration(rand.Intn(2000)+2000) * time.Millisecond)
+			m.NextStateChange = clock.Now().Add(time.Duration(rng.Get(rng.AI).Intn(2000)+2000) * time.Millisecond)
 
 			switch m.State {
 			case MouseIdle:
@@ -220,7 +274,7 @@ func (m *Mouse) Update(worldWidth, worldHeight float32) {
 			case MouseWalking:
 				rl.PlaySound(m.WalkSound)
 				// Use a very slow horizontal speed.
-				m.Speed = rl.NewVector2(float32(rand.Intn(3)-1)*0.05, 0)
+				m.Speed = rl.NewVector2(float32(rng.Get(rng.AI).Intn(3)-1)*0.05, 0)
 			case MouseJumping:
 				rl.PlaySound(m.JumpSound)
 				// Uncomment and adjust if you want an initial upward velocity:
@@ -243,11 +297,11 @@ func (m *Mouse) Update(worldWidth, worldHeight float32) {
 
 		// Optionally, after a fixed duration in the Jumping state, switch back to Idle.
 		// This prevents the mouse from remaining in the Jumping state forever.
-		if time.Since(m.LastStateChange) > 1*time.Second {
+		if clock.Since(m.LastStateChange) > 1*time.Second {
 			m.State = MouseIdle
 			m.CurrentFrame = 0
 			m.FrameCounter = 0
-			m.NextStateChange = time.Now().Add(time.Duration(rand.Intn(2000)+2000) * time.Millisecond)
+			m.NextStateChange = clock.Now().Add(time.Duration(rng.Get(rng.AI).Intn(2000)+2000) * time.Millisecond)
 		}
 	}
 
@@ -309,18 +363,36 @@ func (m *Mouse) Update(worldWidth, worldHeight float32) {
 			if m.CurrentFrame >= len(m.AttackFrames) {
 				m.CurrentFrame = 0
 				m.State = MouseIdle
-				m.NextStateChange = time.Now().Add(time.Duration(rand.Intn(2000)+2000) * time.Millisecond)
+				m.NextStateChange = clock.Now().Add(time.Duration(rng.Get(rng.AI).Intn(2000)+2000) * time.Millisecond)
 			}
 		case MouseSpecial:
 			if m.CurrentFrame >= len(m.SpecialFrames) {
 				m.CurrentFrame = 0
 				m.State = MouseIdle
-				m.NextStateChange = time.Now().Add(time.Duration(rand.Intn(2000)+2000) * time.Millisecond)
+				m.NextStateChange = clock.Now().Add(time.Duration(rng.Get(rng.AI).Intn(2000)+2000) * time.Millisecond)
 			}
 		}
 	}
 }
 
+// scare makes the mouse run away from fromX (or keep running if it already is).
+func (m *Mouse) scare(fromX float32) {
+	dir := float32(1)
+	if fromX > m.Position.X+m.Width/2 {
+		dir = -1
+	}
+	if m.State != MouseWalking || clock.Now().After(m.ScaredUntil) {
+		rl.PlaySound(m.WalkSound)
+		m.State = MouseWalking
+		m.CurrentFrame = 0
+		m.FrameCounter = 0
+		m.LastStateChange = clock.Now()
+	}
+	m.Speed = rl.NewVector2(dir*mouseFleeSpeed, 0)
+	m.ScaredUntil = clock.Now().Add(mouseFleeTime)
+	m.NextStateChange = m.ScaredUntil
+}
+
 // Draw renders the current frame of the mouse based on its state.
 func (m *Mouse) Draw() {
 	var frame rl.Texture2D
@@ -0,0 +1,47 @@
+package gameobjects
+
+import (
+	"platformer-game/ai"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// How far (in world pixels) each kind of noise carries.
+const (
+	NoiseGunshotRadius   = 600
+	NoiseExplosionRadius = 900
+	NoiseRunningRadius   = 250
+	NoiseWalkingRadius   = 90
+	NoiseReloadRadius    = 180
+)
+
+// pendingNoise collects everything made audible this frame. Core drains it
+// once per frame and hands it to the zombies' perception.
+var pendingNoise []ai.Stimulus
+
+// EmitNoise records a noise at pos that can be heard up to radius away.
+func EmitNoise(kind ai.StimulusKind, pos rl.Vector2, radius float32) {
+	pendingNoise = append(pendingNoise, ai.Stimulus{Kind: kind, Position: pos, Radius: radius})
+}
+
+// DrainNoise returns this frame's noises and clears the queue.
+func DrainNoise() []ai.Stimulus {
+	out := pendingNoise
+	pendingNoise = nil
+	return out
+}
+
+// Visibility is how easy the player is to spot: 1 is standing in plain
+// sight, lower values shrink a zombie's sight range.
+func (p *Player) Visibility() float32 {
+	switch p.State {
+	case Sitting, SittingShooting:
+		return 0.5
+	case Resting:
+		return 0.6
+	case Sleeping:
+		return 0.4
+	default:
+		return 1
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"fmt"
 	rl "github.com/gen2brain/raylib-go/raylib"
 	"log"
+	"platformer-game/ai"
+	"platformer-game/clock"
+	"platformer-game/config"
 	"platformer-game/database" // Add this line
+	"platformer-game/input"
 	"platformer-game/rendering"
 	"time"
 )
@@ -61,6 +65,7 @@ type Player struct {
 	switchDown            bool           // Indicates when to start descending
 	throwingFinishedTime  time.Time      // Track when grenade throw animation finishes
 	threwGrenade          bool           // Track if grenade was thrown
+	deathTime             float32        // Seconds since the player died, for the death animation
 	Ammo                  int            // Current ammo count
 	MaxAmmo               int            // Maximum ammo capacity
 	IsReloading           bool           // Flag to check if reloading
@@ -77,6 +82,9 @@ type Player struct {
 	Health    float64   // Player health
 	MaxHealth float64   // Maximum health to keep track for the health bar
 	Inventory Inventory // Player's inventory
+	Equipment Equipment // Worn gear (head, body, accessory)
+	Hotbar    Hotbar    // Number-key quick slots
+	Coins     int       // Currency for merchants
 	HeldItem  Item      // The currently held item
 
 	UsedKeyID string // if non‐empty, means “player just used this key”
@@ -93,9 +101,9 @@ func (inv *Inventory) SaveToDB() {
 	db := database.DB
 	for i, item := range inv.Slots {
 		_, err := db.Exec(`
-			INSERT OR REPLACE INTO inventory (slot, type, name)
-			VALUES (?, ?, ?);`,
-			i, item.Type, item.Name)
+			INSERT OR REPLACE INTO inventory (slot, type, name, durability, count)
+			VALUES (?, ?, ?, ?, ?);`,
+			i, item.Type, item.Name, item.Durability, item.Count)
 		if err != nil {
 			log.Println("Failed to save inventory item:", err)
 		}
@@ -111,9 +119,14 @@ func (p *Player) EquipItem(slotIndex int) {
 
 	switch it.Type {
 	case Weapon:
+		// The weapon in hand, if any, goes back into the slot.
+		held := p.HeldItem
+		if held.Type != Weapon || held.Name == "" {
+			held = Item{Type: Other}
+		}
 		p.HeldItem = it
 		fmt.Printf("Equipped weapon: %s\n", it.Name)
-		p.Inventory.Slots[slotIndex] = Item{Type: Other}
+		p.Inventory.Slots[slotIndex] = held
 		p.Inventory.SaveToDB()
 
 	case HealthPack:
@@ -124,14 +137,31 @@ func (p *Player) EquipItem(slotIndex int) {
 		}
 		fmt.Printf("Used health pack: healed %.0f, now at %.0f/%.0f\n",
 			healAmount, p.Health, p.MaxHealth)
-		p.Inventory.Slots[slotIndex] = Item{Type: Other}
+		p.Inventory.takeOne(slotIndex)
+		p.Inventory.SaveToDB()
+
+	case AmmoType:
+		p.Ammo = p.MaxAmmo
+		p.IsReloading = false
+		fmt.Printf("Used ammo box: %d/%d\n", p.Ammo, p.MaxAmmo)
+		p.Inventory.takeOne(slotIndex)
+		p.Inventory.SaveToDB()
+
+	case GearType:
+		p.Equip(slotIndex)
+
+	case GrenadeType:
+		if !p.ThrowGrenade() {
+			return // still on cooldown; keep the grenade
+		}
+		p.Inventory.takeOne(slotIndex)
 		p.Inventory.SaveToDB()
 
 	case KeyType:
 		// Instead of calling core.UnlockDoor here, just record “I used key X”:
 		p.UsedKeyID = it.Name // e.g. “BronzeKey”
 		fmt.Printf("Used key %q (will notify core to unlock)\n", it.Name)
-		p.Inventory.Slots[slotIndex] = Item{Type: Other}
+		p.Inventory.takeOne(slotIndex)
 		p.Inventory.SaveToDB()
 
 	default:
@@ -140,7 +170,7 @@ func (p *Player) EquipItem(slotIndex int) {
 }
 
 func (p *Player) Shoot() {
-	if rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+	if input.Pressed(input.Fire) {
 		if p.Inventory.IsOpen || p.Inventory.MenuOpen {
 			return
 		}
@@ -158,6 +188,7 @@ func (p *Player) Shoot() {
 			p.Bullets = append(p.Bullets, newBullet)
 
 			p.Ammo-- // Reduce ammo when shooting
+			EmitNoise(ai.StimGunshot, p.Position, NoiseGunshotRadius)
 
 			if !rl.IsSoundPlaying(p.ShootSound) {
 				rl.PlaySound(p.ShootSound)
@@ -179,6 +210,27 @@ func (p *Player) IsGameOver() bool {
 	return p.Health <= 0
 }
 
+const deathFrameTime = 0.4 // seconds each frame of the death animation shows
+
+// UpdateDying plays the death animation and holds its last frame. Returns
+// true once it has finished.
+func (p *Player) UpdateDying(dt float32) bool {
+	if p.State != Dying {
+		p.setState(Dying)
+		p.deathTime = 0
+		rl.StopSound(p.WalkSound)
+		rl.StopSound(p.RunSound)
+	}
+	p.deathTime += dt
+	frame := int(p.deathTime / deathFrameTime)
+	if frame >= len(p.DyingFrames) {
+		p.CurrentFrame = len(p.DyingFrames) - 1
+		return true
+	}
+	p.CurrentFrame = frame
+	return false
+}
+
 func (p *Player) Unload() {
 	for _, frame := range p.WalkFrames {
 		rl.UnloadTexture(frame)
@@ -200,42 +252,60 @@ func (p *Player) Unload() {
 
 var PlayerInstance Player
 
+// Reset puts the player back at the start of a new game: full health and
+// ammo, nothing carried or worn. Sprites and sounds are kept.
+func (p *Player) Reset(worldHeight int) {
+	p.Position = rl.NewVector2(100, float32(worldHeight)-55) // Start at the bottom of the world
+	p.Speed = rl.NewVector2(0, 0)
+	p.CurrentFrame = 0
+	p.FrameCounter = 0
+	p.State = Idle
+	p.IdleTimer = time.Time{}
+	p.RestTimer = time.Time{}
+	p.FacingRight = true
+	p.Health = 100                 // Initialize with full health
+	p.MaxHealth = 100              // Set maximum health
+	p.Inventory = NewInventory(10) // Initialize with 10 slots
+	p.Equipment = NewEquipment()   // Nothing worn yet
+	p.Hotbar = NewHotbar()         // Keys 1-9 → first nine slots
+	p.Coins = 0
+	p.HeldItem = Item{}
+	p.Ammo = 30    // Set starting ammo
+	p.MaxAmmo = 30 // Max ammo capacity
+	p.IsReloading = false
+	p.Bullets = nil
+	p.Explosions = nil
+	p.threwGrenade = false
+	p.switchDown = false
+	p.deathTime = 0
+	p.UsedKeyID = ""
+}
+
 func InitPlayer(worldWidth, worldHeight int) {
 
 	rl.InitAudioDevice() // Initialize audio device
 	PlayerInstance = Player{
-		Position:     rl.NewVector2(100, float32(worldHeight)-55), // Start at the bottom of the world
-		Speed:        rl.NewVector2(0, 0),
 		Acceleration: rl.NewVector2(0, 0.5),
 		Width:        113,
 		Height:       113,
 		Color:        rl.White,
-		CurrentFrame: 0,
-		FrameCounter: 0,
-		State:        Idle,
-		FacingRight:  true,
-		Health:       100,              // Initialize with full health
-		MaxHealth:    100,              // Set maximum health
-		Inventory:    NewInventory(10), // Initialize with 10 slots
-		Ammo:         30,               // Set starting ammo
-		MaxAmmo:      30,               // Max ammo capacity
-		IsReloading:  false,            // Initialize reloading state
 	}
+	PlayerInstance.Reset(worldHeight)
 	// Load sounds
-	PlayerInstance.WalkSound = rl.LoadSound("assets/sounds/walking.mp3")
-	PlayerInstance.RunSound = rl.LoadSound("assets/sounds/running.mp3")
-	PlayerInstance.ShootSound = rl.LoadSound("assets/sounds/machineguneffect.wav")
-	PlayerInstance.ReloadSound = rl.LoadSound("assets/sounds/reload.mp3")
-	PlayerInstance.EmptyClipSound = rl.LoadSound("assets/sounds/emptyclip.mp3")
-	PlayerInstance.GrenadeExplode = rl.LoadSound("assets/sounds/grenade_explosion.mp3")
+	PlayerInstance.WalkSound = rl.LoadSound(config.Asset("sounds/walking.mp3"))
+	PlayerInstance.RunSound = rl.LoadSound(config.Asset("sounds/running.mp3"))
+	PlayerInstance.ShootSound = rl.LoadSound(config.Asset("sounds/machineguneffect.wav"))
+	PlayerInstance.ReloadSound = rl.LoadSound(config.Asset("sounds/reload.mp3"))
+	PlayerInstance.EmptyClipSound = rl.LoadSound(config.Asset("sounds/emptyclip.mp3"))
+	PlayerInstance.GrenadeExplode = rl.LoadSound(config.Asset("sounds/grenade_explosion.mp3"))
 
 	/***********************************LOAD SPRITES*********************************************** */
 
 	// Load sprite sheet
-	spriteSheet := rendering.LoadSpriteSheet("assets/sprites/shooterspritesheet.png")
-	spriteSheet2 := rendering.LoadSpriteSheet("assets/sprites/shooterspritesheet2.png")
-	spriteSheet3 := rendering.LoadSpriteSheet("assets/sprites/shooterspritesheet3.png")
-	spriteSheet4 := rendering.LoadSpriteSheet("assets/sprites/shooterspritesheet4.png")
+	spriteSheet := rendering.LoadSpriteSheet(config.Asset("sprites/shooterspritesheet.png"))
+	spriteSheet2 := rendering.LoadSpriteSheet(config.Asset("sprites/shooterspritesheet2.png"))
+	spriteSheet3 := rendering.LoadSpriteSheet(config.Asset("sprites/shooterspritesheet3.png"))
+	spriteSheet4 := rendering.LoadSpriteSheet(config.Asset("sprites/shooterspritesheet4.png"))
 
 	// Load explosion frame from spritesheet4
 	explosionRect := rl.Rectangle{X: 1600, Y: 346, Width: 157, Height: 93}
@@ -394,10 +464,11 @@ func InitPlayer(worldWidth, worldHeight int) {
 
 }
 
-// Method to throw a grenade and create an explosion
-func (p *Player) ThrowGrenade() {
+// Method to throw a grenade and create an explosion. Returns false if the
+// grenade is still on cooldown.
+func (p *Player) ThrowGrenade() bool {
 	// Check if enough time has passed since the last grenade throw
-	timeSinceThrow := time.Since(p.throwingFinishedTime)
+	timeSinceThrow := clock.Since(p.throwingFinishedTime)
 	//check if end of frames
 
 	// Only allow throwing a grenade if 5 seconds have passed
@@ -418,15 +489,18 @@ func (p *Player) ThrowGrenade() {
 		// Create the explosion object and add it to the player's explosions
 		explosion := NewExplosion(explosionX, explosionY, p.ExplosionTex)
 		p.Explosions = append(p.Explosions, &explosion)
+		EmitNoise(ai.StimExplosion, explosion.Position, NoiseExplosionRadius)
+		AddTrauma(TraumaExplosion)
 
 		// Reset the throwingFinishedTime to the current time for cooldown
-		p.throwingFinishedTime = time.Now()
+		p.throwingFinishedTime = clock.Now()
 
 		fmt.Println("Grenade thrown! Cooldown started.")
-	} else {
-		// If cooldown is still active, notify player or prevent action
-		fmt.Println("Grenade on cooldown. Time remaining:", 5-timeSinceThrow.Seconds(), "seconds")
+		return true
 	}
+	// If cooldown is still active, notify player or prevent action
+	fmt.Println("Grenade on cooldown. Time remaining:", 5-timeSinceThrow.Seconds(), "seconds")
+	return false
 }
 
 /***********************************STATES*********************************************** */
@@ -442,10 +516,10 @@ func (p *Player) setState(state PlayerState) {
 	if state == Idle {
 		//set reloading to false
 		p.IsReloading = false
-		p.IdleTimer = time.Now()
+		p.IdleTimer = clock.Now()
 		p.RestTimer = time.Time{}
 	} else if state == Resting {
-		p.RestTimer = time.Now()
+		p.RestTimer = clock.Now()
 	} else {
 		p.IdleTimer = time.Time{}
 		p.RestTimer = time.Time{}
@@ -551,7 +625,7 @@ func (p *Player) Update(worldHeight int, worldWidth int, zombies []*Zombie) {
 
 	// Player state logic based on key inputs, prioritizing crouching
 	switch {
-	case rl.IsKeyDown(rl.KeyR):
+	case input.Down(input.Reload):
 		fmt.Println("still have ammo: ", p.Ammo)
 
 		if p.State != Reloading && p.Ammo < p.MaxAmmo {
@@ -559,6 +633,7 @@ func (p *Player) Update(worldHeight int, worldWidth int, zombies []*Zombie) {
 			rl.PlaySound(p.ReloadSound)
 			p.setState(Reloading)
 			p.IsReloading = true
+			EmitNoise(ai.StimReload, p.Position, NoiseReloadRadius)
 			p.Speed.X = 0
 			rl.StopSound(p.WalkSound)
 			rl.StopSound(p.RunSound)
@@ -573,9 +648,9 @@ func (p *Player) Update(worldHeight int, worldWidth int, zombies []*Zombie) {
 	//	p.FacingRight = true // Adjust if needed based on player orientation
 	//	p.Speed.X = 0
 	//	rl.StopSound(p.WalkSound)
-	case rl.IsKeyDown(rl.KeyLeftControl):
+	case input.Down(input.Sit):
 		// Crouching has priority, halts forward movement
-		if rl.IsMouseButtonDown(rl.MouseLeftButton) {
+		if input.Down(input.Fire) {
 			if p.Inventory.IsOpen || p.Inventory.MenuOpen {
 				return
 			}
@@ -642,7 +717,7 @@ func (p *Player) Update(worldHeight int, worldWidth int, zombies []*Zombie) {
 	//		}
 	//	}
 	//	// Check for reloading
-	case rl.IsMouseButtonDown(rl.MouseLeftButton) && p.State != Sitting && p.State != SittingShooting:
+	case input.Down(input.Fire) && p.State != Sitting && p.State != SittingShooting:
 		if p.Inventory.IsOpen || p.Inventory.MenuOpen {
 			break
 		}
@@ -663,41 +738,45 @@ func (p *Player) Update(worldHeight int, worldWidth int, zombies []*Zombie) {
 			rl.StopSound(p.RunSound)
 		}
 
-	case rl.IsKeyDown(rl.KeyD) && rl.IsKeyDown(rl.KeyLeftShift) && p.State != Shooting && p.State != Sitting:
+	case input.Down(input.MoveRight) && input.Down(input.Run) && p.State != Shooting && p.State != Sitting:
 		// Running (right) if not shooting or crouching
 		p.setState(Running)
 		p.FacingRight = true
 		p.Speed.X = 0.2
+		EmitNoise(ai.StimFootsteps, p.Position, NoiseRunningRadius)
 		if !rl.IsSoundPlaying(p.RunSound) {
 			rl.PlaySound(p.RunSound)
 		}
 		rl.StopSound(p.WalkSound)
 
-	case rl.IsKeyDown(rl.KeyD) && p.State != Shooting && p.State != Sitting && p.State != SittingShooting:
+	case input.Down(input.MoveRight) && p.State != Shooting && p.State != Sitting && p.State != SittingShooting:
 		// Walking (right) if not shooting or crouching
 		p.setState(Walking)
 		p.FacingRight = true
 		p.Speed.X = 0.05
+		EmitNoise(ai.StimFootsteps, p.Position, NoiseWalkingRadius)
 		if !rl.IsSoundPlaying(p.WalkSound) {
 			rl.PlaySound(p.WalkSound)
 		}
 		rl.StopSound(p.RunSound)
 
-	case rl.IsKeyDown(rl.KeyA) && rl.IsKeyDown(rl.KeyLeftShift) && p.State != Shooting && p.State != Sitting:
+	case input.Down(input.MoveLeft) && input.Down(input.Run) && p.State != Shooting && p.State != Sitting:
 		// Running (left) if not shooting or crouching
 		p.setState(Running)
 		p.FacingRight = false
 		p.Speed.X = -0.2
+		EmitNoise(ai.StimFootsteps, p.Position, NoiseRunningRadius)
 		if !rl.IsSoundPlaying(p.RunSound) {
 			rl.PlaySound(p.RunSound)
 		}
 		rl.StopSound(p.WalkSound)
 
-	case rl.IsKeyDown(rl.KeyA) && p.State != Shooting && p.State != Sitting && p.State != SittingShooting:
+	case input.Down(input.MoveLeft) && p.State != Shooting && p.State != Sitting && p.State != SittingShooting:
 		// Walking (left) if not shooting or crouching
 		p.setState(Walking)
 		p.FacingRight = false
 		p.Speed.X = -0.05
+		EmitNoise(ai.StimFootsteps, p.Position, NoiseWalkingRadius)
 		if !rl.IsSoundPlaying(p.WalkSound) {
 			rl.PlaySound(p.WalkSound)
 		}
@@ -713,12 +792,12 @@ func (p *Player) Update(worldHeight int, worldWidth int, zombies []*Zombie) {
 		rl.StopSound(p.ShootSound)
 	}
 
-	if !rl.IsMouseButtonDown(rl.MouseLeftButton) {
+	if !input.Down(input.Fire) {
 		rl.StopSound(p.ShootSound)
 	}
 
-	// Update horizontal position
-	p.Position.X += p.Speed.X
+	// Update horizontal position (worn gear can make the player quicker)
+	p.Position.X += p.Speed.X * p.moveFactor()
 
 	// this is to constrain player within screen bounds (X-axis)
 	if p.Position.X < 0 {
@@ -745,7 +824,7 @@ func (p *Player) Update(worldHeight int, worldWidth int, zombies []*Zombie) {
 		frames = p.ShootFrames
 	case Reloading:
 		frames = p.ReloadingFrames
-		frameDelay = 1000
+		frameDelay = int(1000 / p.reloadFactor())
 	case Sitting:
 		frames = p.SittingFrames
 	case SittingShooting:
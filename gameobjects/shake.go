@@ -0,0 +1,23 @@
+package gameobjects
+
+// How hard (0-1) each kind of impact shakes the screen.
+const (
+	TraumaExplosion = 0.6 // grenades and the boss's slam
+	TraumaHit       = 0.3 // the player getting hurt
+)
+
+// pendingTrauma adds up this frame's impacts. Core drains it once per frame
+// into the camera.
+var pendingTrauma float32
+
+// AddTrauma shakes the screen by amount.
+func AddTrauma(amount float32) {
+	pendingTrauma += amount
+}
+
+// DrainTrauma returns this frame's trauma and clears it.
+func DrainTrauma() float32 {
+	out := pendingTrauma
+	pendingTrauma = 0
+	return out
+}
@@ -0,0 +1,43 @@
+package gameobjects
+
+import (
+	"database/sql"
+	"log"
+	"platformer-game/database"
+)
+
+// AddCoins gives the player n coins and saves the wallet.
+func (p *Player) AddCoins(n int) {
+	p.Coins += n
+	p.SaveWalletToDB()
+}
+
+// SpendCoins takes n coins if the player has that many. Returns false
+// otherwise, and for n <= 0, which would hand coins out instead.
+func (p *Player) SpendCoins(n int) bool {
+	if n <= 0 || p.Coins < n {
+		return false
+	}
+	p.Coins -= n
+	p.SaveWalletToDB()
+	return true
+}
+
+// SaveWalletToDB writes the current profile's coins.
+func (p *Player) SaveWalletToDB() {
+	_, err := database.DB.Exec(`
+		INSERT OR REPLACE INTO wallet (profile, coins)
+		VALUES (?, ?);`,
+		database.Profile, p.Coins)
+	if err != nil {
+		log.Println("Failed to save wallet:", err)
+	}
+}
+
+// LoadWalletFromDB restores the current profile's coins.
+func (p *Player) LoadWalletFromDB() {
+	row := database.DB.QueryRow(`SELECT coins FROM wallet WHERE profile = ?`, database.Profile)
+	if err := row.Scan(&p.Coins); err != nil && err != sql.ErrNoRows {
+		log.Println("Failed to load wallet from database:", err)
+	}
+}
@@ -1,12 +1,18 @@
 package gameobjects
 
 import (
-	"fmt"
+	"math/rand"
+	"platformer-game/ai"
+	"platformer-game/clock"
+	"platformer-game/config"
+	"platformer-game/nav"
 	"platformer-game/rendering"
+	"platformer-game/rng"
 	"time"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
+
 var gameOver bool // Variable to track game over state
 
 type ZombieState int
@@ -19,13 +25,53 @@ const (
 	ZombieDead
 )
 
+// ZombieType picks the archetype (stat block + look) a zombie is built from.
+type ZombieType int
+
 const (
-	stateSwitchDelay = 3 * time.Second // Delay between state switches
-	frameDelay       = 5000          // Default delay for frame updates
-	attackRange      = 50.0            // Range within which zombie will attack the player
-	followRange      = 300.0           // Range within which zombie will follow the player
+	ZombieWalker ZombieType = iota // the original 100 HP shambler
+	ZombieRunner                   // fragile but fast
+	ZombieBrute                    // slow, big and hits hard
+	ZombieBoss                     // the end-of-level boss (see NewBoss)
 )
 
+func (t ZombieType) String() string {
+	switch t {
+	case ZombieRunner:
+		return "Runner"
+	case ZombieBrute:
+		return "Brute"
+	case ZombieBoss:
+		return "Boss"
+	default:
+		return "Walker"
+	}
+}
+
+// zombieArchetype is the base stat block for a ZombieType.
+type zombieArchetype struct {
+	Health     int
+	SpeedScale float32 // multiplier on wander/chase/flee speed
+	Damage     float64 // damage per landed attack
+	Scale      float32 // sprite size relative to the 113px walker
+	Tint       rl.Color
+}
+
+var zombieArchetypes = map[ZombieType]zombieArchetype{
+	ZombieWalker: {Health: 100, SpeedScale: 1.0, Damage: 8, Scale: 1.0, Tint: rl.Green},
+	ZombieRunner: {Health: 60, SpeedScale: 1.8, Damage: 5, Scale: 0.9, Tint: rl.Orange},
+	ZombieBrute:  {Health: 250, SpeedScale: 0.6, Damage: 18, Scale: 1.3, Tint: rl.Maroon},
+	ZombieBoss:   {Health: 1500, SpeedScale: 0.8, Damage: 20, Scale: 2.0, Tint: rl.Purple},
+}
+
+const (
+	frameDelay  = 5000 // Default delay for frame updates
+	wanderSpeed = 0.02 // Movement per frame while wandering or investigating
+	chaseSpeed  = 0.03 // Movement per frame while chasing the player
+	fleeSpeed   = 0.04 // Movement per frame while running away
+	chargeSpeed = 0.15 // Movement per frame during a boss charge
+	hurtStagger = 0.3  // Seconds a zombie stands still after being hit
+)
 
 var lastIdleSoundTime time.Time // Global cooldown for zombie idle sound
 var isIdleSoundPlaying bool     // Global flag to check if idle sound is currently playing
@@ -33,74 +79,108 @@ var isIdleSoundPlaying bool     // Global flag to check if idle sound is current
 const idleSoundCooldown = 5 * time.Second // Cooldown duration for the idle sound
 const idleSoundProximityRange = 200       // Range within which idle sound plays
 
-
 type Zombie struct {
 	Position        rl.Vector2
 	Speed           rl.Vector2
 	Width, Height   float32
 	Color           rl.Color
-	FacingRight     bool             // Direction the zombie is facing
-	State           ZombieState      // Current animation state
-	FrameCounter    int              // Counter to control frame switch timing
-	CurrentFrame    int              // Current frame index for animation
-	IdleFrames      []rl.Texture2D   // Frames for idle animation
-	WalkFrames      []rl.Texture2D   // Frames for walking animation
-	AttackingFrames []rl.Texture2D   // Frames for attacking animation
-	HurtFrames 	[]rl.Texture2D   // Frames for hurt animation
-	DeadFrames 	[]rl.Texture2D   // Frames for dead animation
-	LastSwitch      time.Time        // Timer for switching states
-	Health          int              // Health points
-    IsAlive         bool             // Whether zombie is alive
+	FacingRight     bool           // Direction the zombie is facing
+	State           ZombieState    // Current animation state
+	FrameCounter    int            // Counter to control frame switch timing
+	CurrentFrame    int            // Current frame index for animation
+	IdleFrames      []rl.Texture2D // Frames for idle animation
+	WalkFrames      []rl.Texture2D // Frames for walking animation
+	AttackingFrames []rl.Texture2D // Frames for attacking animation
+	HurtFrames      []rl.Texture2D // Frames for hurt animation
+	DeadFrames      []rl.Texture2D // Frames for dead animation
+	Health          int            // Health points
+	MaxHealth       int            // Health at spawn (after difficulty scaling)
+	IsAlive         bool           // Whether zombie is alive
+	Type            ZombieType     // Which archetype this zombie was built from
+	SpeedScale      float32        // Multiplier on movement speed
+	Damage          float64        // Damage dealt each time an attack lands
 
 	// Sounds
-    ClawSound       rl.Sound
-    HurtSound       rl.Sound
-    DeathSound      rl.Sound
-	IdleSound       rl.Sound
-	IdleSoundCooldown time.Time        // Cooldown timer for idle sound
+	ClawSound         rl.Sound
+	HurtSound         rl.Sound
+	DeathSound        rl.Sound
+	IdleSound         rl.Sound
+	IdleSoundCooldown time.Time // Cooldown timer for idle sound
+
+	// AI
+	Brain     ai.Brain      // decides what to do each frame
+	Mind      ai.Blackboard // what this zombie knows/remembers
+	Senses    ai.Senses     // sight cone, hearing, memory
+	hurtTimer float32       // seconds left in the hit stagger
+	grudge    float32       // seconds left hunting the companion after it hit us
+
+	// Navigation (nil Nav = old flat-ground behaviour)
+	Nav         *nav.Graph     // graph of the level the zombie is in
+	Path        []nav.Waypoint // waypoints still to visit, exported for debug drawing
+	replanTimer float32        // seconds until the path is recomputed
+	Airborne    bool           // jumping or falling
+	VelY        float32        // vertical speed in px/s while airborne
+	airTargetX  float32        // X the zombie drifts towards while airborne
+
+	// Boss bits
+	IsBoss         bool // no hit stagger, drawn with a boss health bar
+	Telegraphing   bool // winding up a special attack (drawn flashing)
+	PendingSummons int  // minions the brain asked for; core hands them to the spawner
+
+	FromWave bool // spawned as part of a wave, so it counts towards clearing it
 
 }
 
-// Initializing  zombie with default settings and load frames for animations
-func InitZombie(x, y float32, zombieType int) Zombie {
-	spriteSheet := rendering.LoadSpriteSheet("assets/sprites/zombiespritesheet1girl_processed.png")
-	spriteSheet2 := rendering.LoadSpriteSheet("assets/sprites/zombiespritesheet2girl_processed.png")
+// Initializing  zombie with default settings and load frames for animations.
+// y is where a regular walker would stand; bigger archetypes are nudged up so
+// their feet stay on the same ground line.
+func InitZombie(x, y float32, zombieType ZombieType) Zombie {
+	arch, ok := zombieArchetypes[zombieType]
+	if !ok {
+		zombieType = ZombieWalker
+		arch = zombieArchetypes[ZombieWalker]
+	}
+	size := 113 * arch.Scale
+	y -= (size - 113) / 2
+
+	spriteSheet := rendering.LoadSpriteSheet(config.Asset("sprites/zombiespritesheet1girl_processed.png"))
+	spriteSheet2 := rendering.LoadSpriteSheet(config.Asset("sprites/zombiespritesheet2girl_processed.png"))
 
 	// Load sounds for zombie actions
-	clawSound := rl.LoadSound("assets/sounds/zombie_attack.mp3")
-	hurtSound := rl.LoadSound("assets/sounds/zombie_hurt.mp3")
-	deathSound := rl.LoadSound("assets/sounds/zombie_death.mp3")
-	idleSound := rl.LoadSound("assets/sounds/zombie_idle.mp3")
+	clawSound := rl.LoadSound(config.Asset("sounds/zombie_attack.mp3"))
+	hurtSound := rl.LoadSound(config.Asset("sounds/zombie_hurt.mp3"))
+	deathSound := rl.LoadSound(config.Asset("sounds/zombie_death.mp3"))
+	idleSound := rl.LoadSound(config.Asset("sounds/zombie_idle.mp3"))
 
 	// animation frames
 	idleFrames := []rl.Rectangle{
-		{X: 233, Y: 67, Width: 55, Height: 99}, //frame 1
-		{X: 385, Y: 67, Width: 55, Height: 99}, //frame 2
-		{X: 540, Y: 67, Width: 56, Height: 99},//frame 3
-		{X: 694, Y: 67, Width: 59, Height: 99},//frame 4
-		{X: 844, Y: 67, Width: 59, Height: 99},//frame 5
-		{X: 1000, Y: 67, Width: 60, Height: 99},//frame 6
-		{X: 1150, Y: 67, Width: 57, Height: 99},//frame 7
+		{X: 233, Y: 67, Width: 55, Height: 99},  //frame 1
+		{X: 385, Y: 67, Width: 55, Height: 99},  //frame 2
+		{X: 540, Y: 67, Width: 56, Height: 99},  //frame 3
+		{X: 694, Y: 67, Width: 59, Height: 99},  //frame 4
+		{X: 844, Y: 67, Width: 59, Height: 99},  //frame 5
+		{X: 1000, Y: 67, Width: 60, Height: 99}, //frame 6
+		{X: 1150, Y: 67, Width: 57, Height: 99}, //frame 7
 	}
 
 	walkFrames := []rl.Rectangle{
-		{X: 229, Y: 243, Width: 67, Height: 108},//frame 1
-		{X: 380, Y: 244, Width: 72, Height: 107},//frame 2
-		{X: 536, Y: 243, Width: 70, Height: 108},//frame 3
-		{X: 702, Y: 241, Width: 55, Height: 110},//frame 4
-		{X: 837, Y: 241, Width: 73, Height: 110},//frame 5
-		{X: 1000, Y: 241, Width: 66, Height: 110},//frame 6
-		{X: 1150, Y: 241, Width: 68, Height: 110},//frame 7
-		{X: 1308, Y: 241, Width: 64, Height: 110},//frame 8
+		{X: 229, Y: 243, Width: 67, Height: 108},  //frame 1
+		{X: 380, Y: 244, Width: 72, Height: 107},  //frame 2
+		{X: 536, Y: 243, Width: 70, Height: 108},  //frame 3
+		{X: 702, Y: 241, Width: 55, Height: 110},  //frame 4
+		{X: 837, Y: 241, Width: 73, Height: 110},  //frame 5
+		{X: 1000, Y: 241, Width: 66, Height: 110}, //frame 6
+		{X: 1150, Y: 241, Width: 68, Height: 110}, //frame 7
+		{X: 1308, Y: 241, Width: 64, Height: 110}, //frame 8
 	}
 
 	//attacking frames
 	attackingFrames := []rl.Rectangle{
-		{X: 241, Y: 56, Width: 56, Height: 110},//frame 1
-		{X: 387, Y: 54, Width: 51, Height: 112},//frame 2
-		{X: 544, Y: 58, Width: 80, Height: 108},//frame 3
-		{X: 698, Y: 58, Width: 72, Height: 108},//frame 4
-		{X: 837, Y: 59, Width: 71, Height: 107},//frame 5
+		{X: 241, Y: 56, Width: 56, Height: 110}, //frame 1
+		{X: 387, Y: 54, Width: 51, Height: 112}, //frame 2
+		{X: 544, Y: 58, Width: 80, Height: 108}, //frame 3
+		{X: 698, Y: 58, Width: 72, Height: 108}, //frame 4
+		{X: 837, Y: 59, Width: 71, Height: 107}, //frame 5
 	}
 
 	//hurt frames
@@ -117,10 +197,6 @@ func InitZombie(x, y float32, zombieType int) Zombie {
 		{X: 667, Y: 834, Width: 124, Height: 32},
 	}
 
-
-
-
-
 	var idleTextures, walkTextures, attackingTextures, hurtTextures, deadTextures []rl.Texture2D
 	for _, frame := range idleFrames {
 		idleTextures = append(idleTextures, spriteSheet.ImageAt(frame, rl.Blank))
@@ -137,78 +213,78 @@ func InitZombie(x, y float32, zombieType int) Zombie {
 	for _, frame := range deadFrames {
 		deadTextures = append(deadTextures, spriteSheet2.ImageAt(frame, rl.Blank))
 	}
-	
+
 	return Zombie{
 		Position:        rl.Vector2{X: x, Y: y},
 		Speed:           rl.Vector2{X: 0.05, Y: 0},
-		Width:           113,
-		Height:          113,
-		Color:           rl.Green,
+		Width:           size,
+		Height:          size,
+		Color:           arch.Tint,
 		FacingRight:     true,
 		State:           ZombieIdle,
 		IdleFrames:      idleTextures,
 		WalkFrames:      walkTextures,
 		AttackingFrames: attackingTextures,
 		HurtFrames:      hurtTextures,
-		LastSwitch:      time.Now(),
 		DeadFrames:      deadTextures,
-		Health:          100, // Set zombie health
-        IsAlive:         true,
+		Health:          arch.Health, // Set zombie health
+		MaxHealth:       arch.Health,
+		IsAlive:         true,
+		Type:            zombieType,
+		SpeedScale:      arch.SpeedScale,
+		Damage:          arch.Damage,
 
 		// Assign loaded sounds
-        ClawSound:       clawSound,
-        HurtSound:       hurtSound,
-        DeathSound:      deathSound,
-		IdleSound:       idleSound, // Assign idle sound
+		ClawSound:  clawSound,
+		HurtSound:  hurtSound,
+		DeathSound: deathSound,
+		IdleSound:  idleSound, // Assign idle sound
+
+		Brain:  ai.NewZombieBrain(ai.DefaultZombieConfig(), rand.New(rand.NewSource(rng.Get(rng.AI).Int63()))),
+		Mind:   ai.Blackboard{Health: float32(arch.Health), MaxHealth: float32(arch.Health)},
+		Senses: ai.DefaultSenses(),
 	}
 }
 
+// ApplyDifficulty scales health and damage by mult (1 = archetype defaults).
+// Used by the wave spawner to make later waves tougher.
+func (z *Zombie) ApplyDifficulty(mult float32) {
+	z.MaxHealth = int(float32(z.MaxHealth) * mult)
+	z.Health = z.MaxHealth
+	z.Damage *= float64(mult)
+	z.Mind.Health = float32(z.Health)
+	z.Mind.MaxHealth = float32(z.MaxHealth)
+}
+
 // TakeDamage reduces the zombie's health by the specified amount, sets it to hurt or dead if health reaches zero
 func (z *Zombie) TakeDamage(damage int) {
-    z.Health -= damage
-    if z.Health <= 0 {
-        z.Health = 0
-        z.setState(ZombieDead)
-        z.IsAlive = false
-        if !rl.IsSoundPlaying(z.DeathSound) {
-            rl.PlaySound(z.DeathSound)
-        }
-    } else {
-        z.setState(ZombieHurt)
-        if !rl.IsSoundPlaying(z.HurtSound) {
-            rl.PlaySound(z.HurtSound)
-        }
-    }
+	z.Health -= damage
+	if z.Health <= 0 {
+		z.Health = 0
+		z.setState(ZombieDead)
+		z.IsAlive = false
+		if !rl.IsSoundPlaying(z.DeathSound) {
+			rl.PlaySound(z.DeathSound)
+		}
+	} else {
+		// Bosses shrug off hits instead of staggering
+		if !z.IsBoss {
+			z.setState(ZombieHurt)
+			z.hurtTimer = hurtStagger
+		}
+		if !rl.IsSoundPlaying(z.HurtSound) {
+			rl.PlaySound(z.HurtSound)
+		}
+	}
 }
 
-
-// Updating zombie behavior to follow and attack player if within range
-func (z *Zombie) Update(worldWidth int, playerPosition rl.Vector2) {
-    if z.State == ZombieDead && z.CurrentFrame >= len(z.DeadFrames)-1 {
-        // Hold the last death frame, marking the zombie as inactive
-        z.IsAlive = false
-        return
-    }
-
-	// Calculating distance to player for behavior
-	distanceToPlayer := rl.Vector2Distance(z.Position, playerPosition)
-
-	if z.State == ZombieAttacking && distanceToPlayer <= attackRange {
-		if !rl.IsSoundPlaying(z.ClawSound) {
-            rl.PlaySound(z.ClawSound)
-        }
-		//stop other sounds
-		rl.StopSound(z.IdleSound)
-		// Reduce player health when attacked
-		if PlayerInstance.Health > 0 {
-			PlayerInstance.Health -= 0.001 // Adjust damage as needed
-			if PlayerInstance.Health <= 0 {
-				PlayerInstance.Health = 0
-				if PlayerInstance.IsGameOver() {
-					fmt.Println("Game Over: Player Health is 0")
-				}
-			}
-		}
+// Update runs perception and the brain, then turns the brain's decision into
+// movement, animation state and damage against the player.
+func (z *Zombie) Update(worldWidth int, target ai.Target, stimuli []ai.Stimulus, dt float32) {
+	if z.State == ZombieDead && z.CurrentFrame >= len(z.DeadFrames)-1 {
+		// Hold the last death frame, marking the zombie as inactive
+		z.IsAlive = false
+		return
 	}
 
 	// Checking if the zombie's health has reached zero, setting it to dead if so
@@ -217,131 +293,231 @@ func (z *Zombie) Update(worldWidth int, playerPosition rl.Vector2) {
 		z.IsAlive = false // Start death animation but zombie is marked inactive
 		return
 	}
-    if z.IsAlive {
-        switch {
-        case distanceToPlayer <= attackRange:
-            z.setState(ZombieAttacking)
-        case distanceToPlayer <= followRange:
-            z.setState(ZombieWalking)
-			//print th edistance to player
-			//print the idleSoundProximityRange
-			if distanceToPlayer <= idleSoundProximityRange && !isIdleSoundPlaying && time.Since(lastIdleSoundTime) > idleSoundCooldown {
-                rl.PlaySound(z.IdleSound)
-                lastIdleSoundTime = time.Now() // Reset global cooldown timer
-                isIdleSoundPlaying = true      // Set idle sound as currently playing
-            }
-            if playerPosition.X < z.Position.X {
-                z.FacingRight = false
-                z.Speed.X = -0.02 // Slower speed for zombie movement
-            } else {
-                z.FacingRight = true
-                z.Speed.X = 0.02
-            }
-            z.Position.X += z.Speed.X
-        default:
-            // Randomly switch between idle and walking if outside follow range
-            if time.Since(z.LastSwitch) > stateSwitchDelay {
-                if z.State == ZombieIdle {
-                    z.setState(ZombieWalking)
-                } else {
-					//play idle sound
-					
-                    z.setState(ZombieIdle)
-                }
-                z.LastSwitch = time.Now()
-            }
-
-            // Manages edge flipping in walking state
-            if z.State == ZombieWalking {
-                if z.Position.X < 0 || z.Position.X > float32(worldWidth)-z.Width {
-                    z.Speed.X = -z.Speed.X
-                    z.FacingRight = !z.FacingRight
-                }
-                z.Position.X += z.Speed.X
-            }
-        }
-    }
+	if !z.IsAlive {
+		return
+	}
+
+	// Keep the blackboard in sync with the body before thinking
+	z.Mind.Position = z.Position
+	z.Mind.FacingRight = z.FacingRight
+	z.Mind.Health = float32(z.Health)
+
+	// Go after the companion instead if it's the nearer one, or just hit us
+	if z.grudge > 0 {
+		z.grudge -= dt
+	}
+	onCompanion := false
+	if c := ActiveCompanion; c != nil && !c.Downed {
+		targets := []ai.Target{target, {Position: c.Body.Position, Visibility: 1, Provoked: z.grudge > 0}}
+		if z.Senses.ChooseTarget(&z.Mind, targets) == 1 {
+			target, onCompanion = targets[1], true
+		}
+	}
+	z.Senses.Perceive(&z.Mind, target, stimuli, dt)
+
+	// Gravity keeps working even while staggered
+	if z.Airborne {
+		z.fall(dt)
+	}
+
+	// Stagger for a moment after being hit
+	if z.hurtTimer > 0 {
+		z.hurtTimer -= dt
+		return
+	}
 
+	d := z.Brain.Think(&z.Mind, dt)
+	z.FacingRight = z.Mind.FacingRight
+	z.Telegraphing = d.Action == ai.ActWindUp && d.Special != ai.SpecialNone
+	z.PendingSummons += d.Summon
+
+	speed := float32(wanderSpeed)
+	switch d.Action {
+	case ai.ActIdle:
+		z.setState(ZombieIdle)
+	case ai.ActWindUp, ai.ActAttack:
+		if d.Action == ai.ActAttack && d.Special == ai.SpecialCharge {
+			z.setState(ZombieWalking)
+			speed = chargeSpeed
+		} else {
+			z.setState(ZombieAttacking)
+		}
+	case ai.ActChase:
+		z.setState(ZombieWalking)
+		speed = chaseSpeed
+	case ai.ActFlee:
+		z.setState(ZombieWalking)
+		speed = fleeSpeed
+	default: // wander, investigate
+		z.setState(ZombieWalking)
+	}
+
+	// Follow a nav path when the brain has somewhere specific to be
+	moveDir := d.MoveDir
+	following := false
+	if d.HasGoal && z.Nav != nil {
+		if dir, ok := z.steer(d.Goal, dt); ok {
+			moveDir = dir
+			following = true
+		}
+	} else {
+		z.Path = nil
+	}
+
+	if moveDir != 0 && !z.Airborne {
+		step := moveDir * speed * z.SpeedScale
+
+		// Don't stroll off a ledge unless the path says to drop
+		if !following && z.Nav != nil && !z.supportedAt(z.Position.X+step) {
+			z.Mind.WanderDir = -z.Mind.WanderDir
+			step = 0
+		}
+
+		z.FacingRight = moveDir > 0
+		z.Speed.X = step
+		z.Position.X += z.Speed.X
+
+		// Turn around at the world edges
+		if z.Position.X < 0 || z.Position.X > float32(worldWidth)-z.Width {
+			z.Position.X = clampZombieX(z.Position.X, float32(worldWidth)-z.Width)
+			z.Mind.WanderDir = -z.Mind.WanderDir
+		}
+
+		// Walked off an edge: start falling towards the next waypoint
+		if z.Nav != nil && !z.supportedAt(z.Position.X) {
+			z.Airborne = true
+			z.VelY = 0
+			z.airTargetX = z.Position.X + moveDir*dropDrift
+			if len(z.Path) > 0 {
+				z.airTargetX = z.Path[0].Pos.X
+			}
+		}
+	}
+
+	distanceToPlayer := rl.Vector2Distance(z.Position, PlayerInstance.Position)
+
+	// A strike hits whoever we were after; an area attack hits everyone
+	// inside the radius, player and companion alike
+	hitPlayer, hitCompanion := d.Strike && !onCompanion, d.Strike && onCompanion
+	if d.Strike && d.StrikeRadius > 0 {
+		EmitNoise(ai.StimExplosion, z.Feet(), NoiseExplosionRadius)
+		AddTrauma(TraumaExplosion)
+		hitPlayer = distanceToPlayer <= d.StrikeRadius
+		hitCompanion = ActiveCompanion != nil
+	}
+	if hitCompanion {
+		ActiveCompanion.hitBy(z, d.StrikeRadius)
+	}
+	if hitPlayer || hitCompanion {
+		if !rl.IsSoundPlaying(z.ClawSound) {
+			rl.PlaySound(z.ClawSound)
+		}
+		//stop other sounds
+		rl.StopSound(z.IdleSound)
+	}
+	if hitPlayer {
+		// Reduce player health when the attack lands
+		PlayerInstance.TakeDamage(z.Damage)
+	}
+
+	if d.Action == ai.ActChase && distanceToPlayer <= idleSoundProximityRange &&
+		!isIdleSoundPlaying && clock.Since(lastIdleSoundTime) > idleSoundCooldown {
+		rl.PlaySound(z.IdleSound)
+		lastIdleSoundTime = clock.Now() // Reset global cooldown timer
+		isIdleSoundPlaying = true       // Set idle sound as currently playing
+	}
 	if isIdleSoundPlaying && distanceToPlayer > idleSoundProximityRange {
-        rl.StopSound(z.IdleSound)
-        isIdleSoundPlaying = false
-    }
+		rl.StopSound(z.IdleSound)
+		isIdleSoundPlaying = false
+	}
 }
 
+func clampZombieX(x, maxX float32) float32 {
+	if x < 0 {
+		return 0
+	}
+	if x > maxX {
+		return maxX
+	}
+	return x
+}
 
 // Helper method to set zombie state and reset frame data
 func (z *Zombie) setState(state ZombieState) {
-    if z.State != state {
-        // Stop sounds as needed
-        if state == ZombieDead {
-            rl.StopSound(z.ClawSound) // Stop attack sound if zombie dies
+	if z.State != state {
+		// Stop sounds as needed
+		if state == ZombieDead {
+			rl.StopSound(z.ClawSound) // Stop attack sound if zombie dies
 			rl.StopSound(z.IdleSound) // Stop idle sound if zombie dies
-        }
-		
-        
-        z.State = state
-        z.CurrentFrame = 0
-        z.FrameCounter = 0
-    }
+		}
+
+		z.State = state
+		z.CurrentFrame = 0
+		z.FrameCounter = 0
+	}
 }
 func (z *Zombie) UnloadSounds() {
-    rl.UnloadSound(z.ClawSound)
-    rl.UnloadSound(z.HurtSound)
-    rl.UnloadSound(z.DeathSound)
-	rl.UnloadSound(z.IdleSound) 
+	rl.UnloadSound(z.ClawSound)
+	rl.UnloadSound(z.HurtSound)
+	rl.UnloadSound(z.DeathSound)
+	rl.UnloadSound(z.IdleSound)
 }
 
 // Drawing zombie based on the current frame and state
 func (z *Zombie) Draw() {
-    var frames []rl.Texture2D
-    switch z.State {
-    case ZombieWalking:
-        frames = z.WalkFrames
-    case ZombieAttacking:
-        frames = z.AttackingFrames
-    case ZombieHurt:
-        frames = z.HurtFrames
-    case ZombieDead:
-        frames = z.DeadFrames
-    default:
-        frames = z.IdleFrames
-    }
-
-    if len(frames) > 0 {
-        frame := frames[z.CurrentFrame]
-        z.FrameCounter++
-
-        // Differentiate frame timing for the death state
-        if z.State == ZombieDead {
-            if z.FrameCounter >= frameDelay / 20 { // Slower death frame rate
-                if z.CurrentFrame < len(frames)-1 {
-                    z.CurrentFrame++
-                }
-                z.FrameCounter = 0
-            }
-        } else {
-            // Standard frame delay for all other states
-            if z.FrameCounter >= frameDelay / 10 {
-                z.CurrentFrame = (z.CurrentFrame + 1) % len(frames)
-                z.FrameCounter = 0
-            }
-        }
-
-        // Source rectangle setup for animation and flipping
-        sourceRect := rl.Rectangle{X: 0, Y: 0, Width: float32(frame.Width), Height: float32(frame.Height)}
-        if !z.FacingRight {
-            sourceRect.Width = -sourceRect.Width
-        }
-
-        // Drawing the current frame
-        destinationRect := rl.Rectangle{
-            X:      z.Position.X,
-            Y:      z.Position.Y,
-            Width:  z.Width,
-            Height: z.Height,
-        }
-        if frame.ID != 0 {
-            rl.DrawTexturePro(frame, sourceRect, destinationRect, rl.Vector2{X: z.Width / 2, Y: z.Height / 2}, 0, z.Color)
-        }
-    }
+	var frames []rl.Texture2D
+	switch z.State {
+	case ZombieWalking:
+		frames = z.WalkFrames
+	case ZombieAttacking:
+		frames = z.AttackingFrames
+	case ZombieHurt:
+		frames = z.HurtFrames
+	case ZombieDead:
+		frames = z.DeadFrames
+	default:
+		frames = z.IdleFrames
+	}
+
+	if len(frames) > 0 {
+		frame := frames[z.CurrentFrame]
+		z.FrameCounter++
+
+		// Differentiate frame timing for the death state
+		if z.State == ZombieDead {
+			if z.FrameCounter >= frameDelay/20 { // Slower death frame rate
+				if z.CurrentFrame < len(frames)-1 {
+					z.CurrentFrame++
+				}
+				z.FrameCounter = 0
+			}
+		} else {
+			// Standard frame delay for all other states
+			if z.FrameCounter >= frameDelay/10 {
+				z.CurrentFrame = (z.CurrentFrame + 1) % len(frames)
+				z.FrameCounter = 0
+			}
+		}
+
+		// Source rectangle setup for animation and flipping
+		sourceRect := rl.Rectangle{X: 0, Y: 0, Width: float32(frame.Width), Height: float32(frame.Height)}
+		if !z.FacingRight {
+			sourceRect.Width = -sourceRect.Width
+		}
+
+		// Drawing the current frame
+		destinationRect := rl.Rectangle{
+			X:      z.Position.X,
+			Y:      z.Position.Y,
+			Width:  z.Width,
+			Height: z.Height,
+		}
+		tint := z.Color
+		if z.Telegraphing && int(rl.GetTime()*8)%2 == 0 {
+			tint = rl.White // flash while winding up a special attack
+		}
+		if frame.ID != 0 {
+			rl.DrawTexturePro(frame, sourceRect, destinationRect, rl.Vector2{X: z.Width / 2, Y: z.Height / 2}, 0, tint)
+		}
+	}
 }
@@ -0,0 +1,109 @@
+package gameobjects
+
+import (
+	"math"
+	"platformer-game/nav"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	zombieFootInset  = 6.5  // sprite feet sit this far above the bottom of the frame
+	playerFootOffset = 55   // PlayerInstance.Position is its centre; its feet are this far below
+	zombieGravity    = 1800 // px/s² while airborne
+	jumpClearance    = 30   // extra height on top of the ledge so the jump clears it
+	airSpeed         = 160  // horizontal px/s while airborne
+	dropDrift        = 20   // how far past the edge a zombie drifts when it drops without a path
+	replanInterval   = 0.5  // seconds between path recomputations
+	waypointReach    = 8    // how close (px) counts as "at" a waypoint
+)
+
+// Feet returns the point the zombie is standing on.
+func (z *Zombie) Feet() rl.Vector2 {
+	return rl.Vector2{X: z.Position.X, Y: z.Position.Y + z.Height/2 - zombieFootInset}
+}
+
+// supportedAt reports whether there is floor under the zombie's feet at x.
+func (z *Zombie) supportedAt(x float32) bool {
+	feetY := z.Feet().Y
+	_, ok := z.Nav.Support(x, feetY, feetY+1)
+	return ok
+}
+
+// steer follows (and periodically re-plans) a nav path towards goal, which is
+// in Target coordinates (the player's centre). It returns the direction to
+// walk this frame, or ok=false when there's no route and the brain's own
+// MoveDir should be used instead.
+func (z *Zombie) steer(goal rl.Vector2, dt float32) (dir float32, ok bool) {
+	feet := z.Feet()
+	z.replanTimer -= dt
+	if z.replanTimer <= 0 {
+		z.Path = z.Nav.FindPath(feet, rl.Vector2{X: goal.X, Y: goal.Y + playerFootOffset})
+		z.replanTimer = replanInterval
+		if z.Path == nil {
+			return 0, false
+		}
+	}
+	if z.Airborne {
+		return 0, true // fall() handles the air
+	}
+
+	for len(z.Path) > 0 {
+		wp := z.Path[0]
+		dx := wp.Pos.X - feet.X
+		dy := wp.Pos.Y - feet.Y
+		if float32(math.Abs(float64(dx))) <= waypointReach && float32(math.Abs(float64(dy))) < 6 {
+			z.Path = z.Path[1:]
+			continue
+		}
+		if wp.Kind == nav.LinkJump {
+			z.jump(wp.Pos)
+			return 0, true
+		}
+		if dx < 0 {
+			return -1, true
+		}
+		return 1, true
+	}
+	return 0, true
+}
+
+// jump launches the zombie towards a waypoint on another surface.
+func (z *Zombie) jump(to rl.Vector2) {
+	rise := z.Feet().Y - to.Y
+	if rise < 0 {
+		rise = 0
+	}
+	z.VelY = -float32(math.Sqrt(float64(2 * zombieGravity * (rise + jumpClearance))))
+	z.airTargetX = to.X
+	z.Airborne = true
+	z.FacingRight = to.X >= z.Position.X
+	z.Path = z.Path[1:]
+}
+
+// fall applies gravity while airborne, drifts towards airTargetX and lands on
+// the first floor crossed on the way down (platforms are one-way from below).
+func (z *Zombie) fall(dt float32) {
+	if dx := z.airTargetX - z.Position.X; math.Abs(float64(dx)) > 1 {
+		step := float32(airSpeed) * dt
+		if dx < 0 {
+			step = -step
+		}
+		if math.Abs(float64(step)) > math.Abs(float64(dx)) {
+			step = dx
+		}
+		z.Position.X += step
+	}
+
+	oldFeet := z.Feet().Y
+	z.VelY += zombieGravity * dt
+	z.Position.Y += z.VelY * dt
+	if z.VelY <= 0 {
+		return
+	}
+	if floor, ok := z.Nav.Support(z.Position.X, oldFeet, z.Feet().Y); ok {
+		z.Position.Y = floor - z.Height/2 + zombieFootInset
+		z.VelY = 0
+		z.Airborne = false
+	}
+}
@@ -0,0 +1,141 @@
+// Package input turns the keyboard, mouse and gamepad into named actions
+// (move left, fire, interact...). The game asks about actions rather than
+// keys, so the player can rebind them and tests or replays can feed in
+// their own input through a Source.
+package input
+
+import "fmt"
+
+// Action is something the player can do.
+type Action int
+
+const (
+	MoveLeft Action = iota
+	MoveRight
+	Run
+	Sit
+	Jump // not used yet: jumping is switched off in Player.Update
+	Fire
+	Reload
+	Interact
+	Inventory
+	QuestLog
+	Crafting
+	Talk
+	Revive
+	Confirm  // advance or pick in a conversation
+	MenuUp   // move a conversation's choice cursor up...
+	MenuDown // ...and down
+	MenuLeft
+	MenuRight
+	Back       // close the open panels
+	Click      // left mouse button, for the inventory, shop and door menus...
+	RightClick // ...and the right one
+	Slot1      // number keys 1-9: hotbar slots and conversation choices
+	Slot2
+	Slot3
+	Slot4
+	Slot5
+	Slot6
+	Slot7
+	Slot8
+	Slot9
+	NumActions
+)
+
+var actionInfo = [NumActions]struct {
+	id   string // used in the settings file
+	name string // shown in menus
+}{
+	MoveLeft:   {"move_left", "Move left"},
+	MoveRight:  {"move_right", "Move right"},
+	Run:        {"run", "Run"},
+	Sit:        {"sit", "Sit"},
+	Jump:       {"jump", "Jump"},
+	Fire:       {"fire", "Fire"},
+	Reload:     {"reload", "Reload"},
+	Interact:   {"interact", "Interact"},
+	Inventory:  {"inventory", "Inventory"},
+	QuestLog:   {"quest_log", "Quest log"},
+	Crafting:   {"crafting", "Crafting"},
+	Talk:       {"talk", "Talk"},
+	Revive:     {"revive", "Revive"},
+	Confirm:    {"confirm", "Confirm"},
+	MenuUp:     {"menu_up", "Menu up"},
+	MenuDown:   {"menu_down", "Menu down"},
+	MenuLeft:   {"menu_left", "Menu left"},
+	MenuRight:  {"menu_right", "Menu right"},
+	Back:       {"back", "Back"},
+	Click:      {"click", "Click"},
+	RightClick: {"right_click", "Right click"},
+	Slot1:      {"slot_1", "Slot 1"},
+	Slot2:      {"slot_2", "Slot 2"},
+	Slot3:      {"slot_3", "Slot 3"},
+	Slot4:      {"slot_4", "Slot 4"},
+	Slot5:      {"slot_5", "Slot 5"},
+	Slot6:      {"slot_6", "Slot 6"},
+	Slot7:      {"slot_7", "Slot 7"},
+	Slot8:      {"slot_8", "Slot 8"},
+	Slot9:      {"slot_9", "Slot 9"},
+}
+
+// Rebindable are the actions the settings screen lists, in order. The rest
+// keep their default controls.
+var Rebindable = []Action{
+	MoveLeft, MoveRight, Run, Sit, Fire, Reload, Interact, Inventory, QuestLog, Crafting, Talk, Revive,
+}
+
+func (a Action) String() string {
+	if a < 0 || a >= NumActions {
+		return fmt.Sprintf("Action(%d)", int(a))
+	}
+	return actionInfo[a].name
+}
+
+// MarshalText writes an action by its ID, so saved bindings read as
+// "interact" rather than a number.
+func (a Action) MarshalText() ([]byte, error) {
+	if a < 0 || a >= NumActions {
+		return nil, fmt.Errorf("unknown action %d", int(a))
+	}
+	return []byte(actionInfo[a].id), nil
+}
+
+func (a *Action) UnmarshalText(text []byte) error {
+	for i, info := range actionInfo {
+		if info.id == string(text) {
+			*a = Action(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown action %q", text)
+}
+
+// Slot is the number key action for slot i (0-8), or NumActions past the
+// ninth.
+func Slot(i int) Action {
+	if i < 0 || i > 8 {
+		return NumActions
+	}
+	return Slot1 + Action(i)
+}
+
+// Set is a set of actions, one bit each.
+type Set uint32
+
+func (s Set) Has(a Action) bool {
+	return s&(1<<uint(a)) != 0
+}
+
+func (s Set) With(a Action) Set {
+	return s | 1<<uint(a)
+}
+
+func rebindable(a Action) bool {
+	for _, r := range Rebindable {
+		if r == a {
+			return true
+		}
+	}
+	return false
+}
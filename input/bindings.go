@@ -0,0 +1,173 @@
+package input
+
+import (
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const stickDeadZone = 0.5 // how far a stick has to be pushed to count
+
+// Stick is one direction of a gamepad axis, e.g. the left stick pushed left.
+type Stick struct {
+	Axis int32   `json:"axis"`
+	Dir  float32 `json:"dir"` // -1 or +1
+}
+
+// Binding is every physical input that triggers an action; any one of them
+// will do.
+type Binding struct {
+	Keys    []int32          `json:"keys,omitempty"`
+	Mouse   []rl.MouseButton `json:"mouse,omitempty"`
+	Buttons []int32          `json:"buttons,omitempty"` // gamepad buttons
+	Sticks  []Stick          `json:"sticks,omitempty"`
+}
+
+// Bindings maps each action to its inputs.
+type Bindings map[Action]Binding
+
+// Controls are the bindings the Raylib source reads.
+var Controls = DefaultBindings()
+
+// DefaultBindings are the controls the game ships with.
+func DefaultBindings() Bindings {
+	return Bindings{
+		MoveLeft:   {Keys: []int32{rl.KeyA}, Sticks: []Stick{{rl.GamepadAxisLeftX, -1}}},
+		MoveRight:  {Keys: []int32{rl.KeyD}, Sticks: []Stick{{rl.GamepadAxisLeftX, 1}}},
+		Run:        {Keys: []int32{rl.KeyLeftShift}, Buttons: []int32{rl.GamepadButtonLeftTrigger1}},
+		Sit:        {Keys: []int32{rl.KeyLeftControl}, Buttons: []int32{rl.GamepadButtonLeftTrigger2}},
+		Jump:       {Keys: []int32{rl.KeySpace}},
+		Fire:       {Mouse: []rl.MouseButton{rl.MouseLeftButton}, Buttons: []int32{rl.GamepadButtonRightTrigger2}},
+		Reload:     {Keys: []int32{rl.KeyR}, Buttons: []int32{rl.GamepadButtonRightFaceLeft}},
+		Interact:   {Keys: []int32{rl.KeyE}, Buttons: []int32{rl.GamepadButtonRightFaceRight}},
+		Inventory:  {Keys: []int32{rl.KeyI}, Buttons: []int32{rl.GamepadButtonRightFaceUp}},
+		QuestLog:   {Keys: []int32{rl.KeyJ}, Buttons: []int32{rl.GamepadButtonMiddleLeft}},
+		Crafting:   {Keys: []int32{rl.KeyC}},
+		Talk:       {Keys: []int32{rl.KeyT}, Buttons: []int32{rl.GamepadButtonRightTrigger1}},
+		Revive:     {Keys: []int32{rl.KeyF}, Buttons: []int32{rl.GamepadButtonRightFaceDown}},
+		Confirm:    {Keys: []int32{rl.KeySpace, rl.KeyEnter}, Buttons: []int32{rl.GamepadButtonRightFaceDown}},
+		MenuUp:     {Keys: []int32{rl.KeyUp}, Buttons: []int32{rl.GamepadButtonLeftFaceUp}},
+		MenuDown:   {Keys: []int32{rl.KeyDown}, Buttons: []int32{rl.GamepadButtonLeftFaceDown}},
+		MenuLeft:   {Keys: []int32{rl.KeyLeft}, Buttons: []int32{rl.GamepadButtonLeftFaceLeft}},
+		MenuRight:  {Keys: []int32{rl.KeyRight}, Buttons: []int32{rl.GamepadButtonLeftFaceRight}},
+		Back:       {Keys: []int32{rl.KeyEscape}},
+		Click:      {Mouse: []rl.MouseButton{rl.MouseLeftButton}},
+		RightClick: {Mouse: []rl.MouseButton{rl.MouseRightButton}},
+		Slot1:      {Keys: []int32{rl.KeyOne}},
+		Slot2:      {Keys: []int32{rl.KeyTwo}},
+		Slot3:      {Keys: []int32{rl.KeyThree}},
+		Slot4:      {Keys: []int32{rl.KeyFour}},
+		Slot5:      {Keys: []int32{rl.KeyFive}},
+		Slot6:      {Keys: []int32{rl.KeySix}},
+		Slot7:      {Keys: []int32{rl.KeySeven}},
+		Slot8:      {Keys: []int32{rl.KeyEight}},
+		Slot9:      {Keys: []int32{rl.KeyNine}},
+	}
+}
+
+// Raylib is the Source that reads the real keyboard, mouse and first
+// gamepad through Controls.
+type Raylib struct{}
+
+func (Raylib) Poll() Frame {
+	f := Frame{Mouse: rl.GetMousePosition(), Wheel: rl.GetMouseWheelMove()}
+	pad := rl.IsGamepadAvailable(0)
+	for a, b := range Controls {
+		if b.down(pad) {
+			f.Actions = f.Actions.With(a)
+		}
+	}
+	return f
+}
+
+func (b Binding) down(pad bool) bool {
+	for _, k := range b.Keys {
+		if rl.IsKeyDown(k) {
+			return true
+		}
+	}
+	for _, m := range b.Mouse {
+		if rl.IsMouseButtonDown(m) {
+			return true
+		}
+	}
+	if !pad {
+		return false
+	}
+	for _, btn := range b.Buttons {
+		if rl.IsGamepadButtonDown(0, btn) {
+			return true
+		}
+	}
+	for _, st := range b.Sticks {
+		if rl.GetGamepadAxisMovement(0, st.Axis)*st.Dir > stickDeadZone {
+			return true
+		}
+	}
+	return false
+}
+
+// Rebind replaces the keyboard key (or, with pad set, the gamepad button)
+// of action a. Another action using that input swaps over to a's old one,
+// so two rebindable actions never end up on the same key.
+func (bs Bindings) Rebind(a Action, input int32, pad bool) {
+	b := bs[a]
+	old := b.Keys
+	if pad {
+		old = b.Buttons
+	}
+	for other, ob := range bs {
+		if other == a || !rebindable(other) {
+			continue
+		}
+		list := &ob.Keys
+		if pad {
+			list = &ob.Buttons
+		}
+		for i, v := range *list {
+			if v == input {
+				*list = append(append([]int32{}, (*list)[:i]...), (*list)[i+1:]...)
+				*list = append(*list, old...)
+				bs[other] = ob
+				break
+			}
+		}
+	}
+	if pad {
+		b.Buttons = []int32{input}
+	} else {
+		b.Keys = []int32{input}
+	}
+	bs[a] = b
+}
+
+// String describes a binding for menus, e.g. "E / Pad B".
+func (b Binding) String() string {
+	var parts []string
+	for _, k := range b.Keys {
+		parts = append(parts, KeyName(k))
+	}
+	for _, m := range b.Mouse {
+		parts = append(parts, mouseNames[m])
+	}
+	for _, btn := range b.Buttons {
+		parts = append(parts, "Pad "+buttonNames[btn])
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " / ")
+}
+
+// PressedButton returns the gamepad button pressed this frame, or -1.
+func PressedButton() int32 {
+	if !rl.IsGamepadAvailable(0) {
+		return -1
+	}
+	for btn := int32(rl.GamepadButtonLeftFaceUp); btn <= rl.GamepadButtonRightThumb; btn++ {
+		if rl.IsGamepadButtonPressed(0, btn) {
+			return btn
+		}
+	}
+	return -1
+}
@@ -0,0 +1,98 @@
+package input
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// Frame is one frame of input: the actions held down, and the mouse.
+type Frame struct {
+	Actions Set
+	Mouse   rl.Vector2 // position on the virtual screen
+	Wheel   float32    // how far the wheel turned this frame
+}
+
+// Source tells which actions are held down and where the mouse is. Poll is
+// called once per frame.
+type Source interface {
+	Poll() Frame
+}
+
+var (
+	source     Source = Raylib{}
+	down, prev Frame
+)
+
+// SetSource makes the game read its input from s; nil goes back to the
+// keyboard, mouse and gamepad.
+func SetSource(s Source) {
+	if s == nil {
+		s = Raylib{}
+	}
+	source = s
+}
+
+// Update reads this frame's input. Call it once at the start of every frame,
+// before anything asks about actions.
+func Update() {
+	prev = down
+	down = source.Poll()
+}
+
+// Current returns this frame's input.
+func Current() Frame {
+	return down
+}
+
+// Down reports whether a is held down.
+func Down(a Action) bool {
+	return down.Actions.Has(a)
+}
+
+// Pressed reports whether a went down this frame.
+func Pressed(a Action) bool {
+	return down.Actions.Has(a) && !prev.Actions.Has(a)
+}
+
+// Released reports whether a was let go this frame.
+func Released(a Action) bool {
+	return !down.Actions.Has(a) && prev.Actions.Has(a)
+}
+
+// Mouse returns where the mouse is on the virtual screen. The menus that
+// change the game read it from here rather than from raylib, so a replay
+// clicks in the same places.
+func Mouse() rl.Vector2 {
+	return down.Mouse
+}
+
+// MouseMoved reports whether the mouse moved since last frame.
+func MouseMoved() bool {
+	return down.Mouse != prev.Mouse
+}
+
+// Wheel returns how far the mouse wheel turned this frame.
+func Wheel() float32 {
+	return down.Wheel
+}
+
+// Script is a Source that plays back a fixed list of frames, one per Poll,
+// and then nothing, with the mouse left where it was. Tests and replays use
+// it to drive the game.
+type Script struct {
+	Frames []Frame
+	next   int
+	last   rl.Vector2
+}
+
+func (s *Script) Poll() Frame {
+	if s.next >= len(s.Frames) {
+		return Frame{Mouse: s.last}
+	}
+	f := s.Frames[s.next]
+	s.next++
+	s.last = f.Mouse
+	return f
+}
+
+// Done reports whether every frame has been played.
+func (s *Script) Done() bool {
+	return s.next >= len(s.Frames)
+}
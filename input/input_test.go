@@ -0,0 +1,219 @@
+package input
+
+import (
+	"slices"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// play makes the game read from a script of frames, and puts the real
+// controls back after the test.
+func play(t *testing.T, frames ...Frame) *Script {
+	t.Helper()
+	s := &Script{Frames: frames}
+	SetSource(s)
+	down, prev = Frame{}, Frame{}
+	t.Cleanup(func() {
+		SetSource(nil)
+		down, prev = Frame{}, Frame{}
+	})
+	return s
+}
+
+// held is a frame with actions held down and the mouse in the corner.
+func held(actions ...Action) Frame {
+	return Frame{Actions: set(actions...)}
+}
+
+func set(actions ...Action) Set {
+	var s Set
+	for _, a := range actions {
+		s = s.With(a)
+	}
+	return s
+}
+
+func TestScript(t *testing.T) {
+	frames := []Frame{
+		held(MoveLeft),
+		{Actions: set(MoveLeft, Fire), Mouse: rl.NewVector2(100, 50), Wheel: -1},
+		{Mouse: rl.NewVector2(120, 60)},
+	}
+	s := play(t, frames...)
+	for i, want := range frames {
+		if s.Done() {
+			t.Fatalf("Done before frame %d", i)
+		}
+		Update()
+		if Current() != want {
+			t.Errorf("frame %d: %+v, want %+v", i, Current(), want)
+		}
+	}
+	if !s.Done() {
+		t.Error("not Done after the last frame")
+	}
+	Update()
+	if want := (Frame{Mouse: rl.NewVector2(120, 60)}); Current() != want {
+		t.Errorf("after the script ran out: %+v, want nothing held and the mouse left where it was", Current())
+	}
+}
+
+func TestEdges(t *testing.T) {
+	play(t,
+		held(Fire),         // 0: pressed
+		held(Fire),         // 1: held
+		held(Fire, Reload), // 2: still held, reload pressed
+		held(Reload),       // 3: fire released
+		held(),             // 4: reload released
+		held(Fire),         // 5: pressed again
+	)
+	tests := []struct {
+		down, pressed, released []Action
+	}{
+		{down: []Action{Fire}, pressed: []Action{Fire}},
+		{down: []Action{Fire}},
+		{down: []Action{Fire, Reload}, pressed: []Action{Reload}},
+		{down: []Action{Reload}, released: []Action{Fire}},
+		{released: []Action{Reload}},
+		{down: []Action{Fire}, pressed: []Action{Fire}},
+	}
+	for i, tt := range tests {
+		Update()
+		for _, a := range []Action{Fire, Reload, MoveLeft} {
+			if got, want := Down(a), slices.Contains(tt.down, a); got != want {
+				t.Errorf("frame %d: Down(%v) = %v, want %v", i, a, got, want)
+			}
+			if got, want := Pressed(a), slices.Contains(tt.pressed, a); got != want {
+				t.Errorf("frame %d: Pressed(%v) = %v, want %v", i, a, got, want)
+			}
+			if got, want := Released(a), slices.Contains(tt.released, a); got != want {
+				t.Errorf("frame %d: Released(%v) = %v, want %v", i, a, got, want)
+			}
+		}
+	}
+}
+
+func TestMouse(t *testing.T) {
+	play(t,
+		Frame{Mouse: rl.NewVector2(10, 20)},
+		Frame{Mouse: rl.NewVector2(10, 20), Wheel: 2},
+		Frame{Mouse: rl.NewVector2(30, 20)},
+	)
+	tests := []struct {
+		mouse rl.Vector2
+		moved bool
+		wheel float32
+	}{
+		{rl.NewVector2(10, 20), true, 0}, // from the corner
+		{rl.NewVector2(10, 20), false, 2},
+		{rl.NewVector2(30, 20), true, 0},
+		{rl.NewVector2(30, 20), false, 0}, // the script has run out
+	}
+	for i, tt := range tests {
+		Update()
+		if Mouse() != tt.mouse || MouseMoved() != tt.moved || Wheel() != tt.wheel {
+			t.Errorf("frame %d: mouse %v moved %v wheel %v, want %v %v %v",
+				i, Mouse(), MouseMoved(), Wheel(), tt.mouse, tt.moved, tt.wheel)
+		}
+	}
+}
+
+func TestSlot(t *testing.T) {
+	for i := range 9 {
+		if got := Slot(i); got != Slot1+Action(i) {
+			t.Errorf("Slot(%d) = %v, want slot %d", i, got, i+1)
+		}
+	}
+	for _, i := range []int{-1, 9} {
+		if got := Slot(i); got != NumActions {
+			t.Errorf("Slot(%d) = %v, want no action", i, got)
+		}
+	}
+	play(t, held(Slot3))
+	Update()
+	if !Pressed(Slot(2)) || Pressed(Slot(9)) {
+		t.Error("number key 3 didn't press slot 3, or pressed slot 10")
+	}
+}
+
+func TestSetSourceNil(t *testing.T) {
+	play(t)
+	SetSource(nil)
+	if _, ok := source.(Raylib); !ok {
+		t.Errorf("SetSource(nil) left %T, want the real controls", source)
+	}
+}
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     Action
+		input int32
+		pad   bool
+		want  map[Action][]int32 // keys (or buttons, with pad) afterwards
+	}{
+		{
+			name: "free key", a: Reload, input: rl.KeyG,
+			want: map[Action][]int32{Reload: {rl.KeyG}, Interact: {rl.KeyE}},
+		},
+		{
+			name: "taken key swaps", a: Reload, input: rl.KeyE,
+			want: map[Action][]int32{Reload: {rl.KeyE}, Interact: {rl.KeyR}},
+		},
+		{
+			name: "same key", a: Reload, input: rl.KeyR,
+			want: map[Action][]int32{Reload: {rl.KeyR}},
+		},
+		{
+			name: "fixed controls keep their key", a: Talk, input: rl.KeySpace,
+			want: map[Action][]int32{Talk: {rl.KeySpace}, Confirm: {rl.KeySpace, rl.KeyEnter}, Jump: {rl.KeySpace}},
+		},
+		{
+			name: "pad button swaps", a: Reload, input: rl.GamepadButtonRightFaceRight, pad: true,
+			want: map[Action][]int32{Reload: {rl.GamepadButtonRightFaceRight}, Interact: {rl.GamepadButtonRightFaceLeft}},
+		},
+		{
+			name: "pad button to an action without one", a: Crafting, input: rl.GamepadButtonRightFaceUp, pad: true,
+			want: map[Action][]int32{Crafting: {rl.GamepadButtonRightFaceUp}, Inventory: {}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bs := DefaultBindings()
+			bs.Rebind(tt.a, tt.input, tt.pad)
+			for a, want := range tt.want {
+				got := bs[a].Keys
+				if tt.pad {
+					got = bs[a].Buttons
+				}
+				if !slices.Equal(got, want) {
+					t.Errorf("%v: %v, want %v", a, got, want)
+				}
+			}
+			if tt.pad && !slices.Equal(bs[tt.a].Keys, DefaultBindings()[tt.a].Keys) {
+				t.Errorf("rebinding a pad button changed the keys of %v", tt.a)
+			}
+		})
+	}
+}
+
+func TestActionText(t *testing.T) {
+	for a := Action(0); a < NumActions; a++ {
+		text, err := a.MarshalText()
+		if err != nil {
+			t.Fatalf("%v: %v", a, err)
+		}
+		var back Action
+		if err := back.UnmarshalText(text); err != nil || back != a {
+			t.Errorf("%q read back as %v (%v), want %v", text, back, err, a)
+		}
+	}
+	var a Action
+	if err := a.UnmarshalText([]byte("dance")); err == nil {
+		t.Error("unknown action read without an error")
+	}
+	if _, err := NumActions.MarshalText(); err == nil {
+		t.Error("NumActions written without an error")
+	}
+}
@@ -0,0 +1,65 @@
+package input
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+var keyNames = map[int32]string{
+	rl.KeySpace:        "Space",
+	rl.KeyEnter:        "Enter",
+	rl.KeyTab:          "Tab",
+	rl.KeyBackspace:    "Backspace",
+	rl.KeyLeftShift:    "Left Shift",
+	rl.KeyRightShift:   "Right Shift",
+	rl.KeyLeftControl:  "Left Ctrl",
+	rl.KeyRightControl: "Right Ctrl",
+	rl.KeyLeftAlt:      "Left Alt",
+	rl.KeyRightAlt:     "Right Alt",
+	rl.KeyUp:           "Up",
+	rl.KeyDown:         "Down",
+	rl.KeyLeft:         "Left",
+	rl.KeyRight:        "Right",
+}
+
+// KeyName is how a keyboard key is shown in menus, e.g. "E" or "Left Shift".
+func KeyName(key int32) string {
+	switch {
+	case key >= rl.KeyA && key <= rl.KeyZ, key >= rl.KeyZero && key <= rl.KeyNine:
+		return string(rune(key))
+	case key >= rl.KeyF1 && key <= rl.KeyF12:
+		return fmt.Sprintf("F%d", key-rl.KeyF1+1)
+	}
+	if name, ok := keyNames[key]; ok {
+		return name
+	}
+	return fmt.Sprintf("Key %d", key)
+}
+
+var mouseNames = map[rl.MouseButton]string{
+	rl.MouseLeftButton:   "Mouse Left",
+	rl.MouseRightButton:  "Mouse Right",
+	rl.MouseMiddleButton: "Mouse Middle",
+}
+
+// Gamepad buttons, named as on an Xbox pad
+var buttonNames = map[int32]string{
+	rl.GamepadButtonLeftFaceUp:     "Up",
+	rl.GamepadButtonLeftFaceRight:  "Right",
+	rl.GamepadButtonLeftFaceDown:   "Down",
+	rl.GamepadButtonLeftFaceLeft:   "Left",
+	rl.GamepadButtonRightFaceUp:    "Y",
+	rl.GamepadButtonRightFaceRight: "B",
+	rl.GamepadButtonRightFaceDown:  "A",
+	rl.GamepadButtonRightFaceLeft:  "X",
+	rl.GamepadButtonLeftTrigger1:   "LB",
+	rl.GamepadButtonLeftTrigger2:   "LT",
+	rl.GamepadButtonRightTrigger1:  "RB",
+	rl.GamepadButtonRightTrigger2:  "RT",
+	rl.GamepadButtonMiddleLeft:     "Back",
+	rl.GamepadButtonMiddle:         "Guide",
+	rl.GamepadButtonMiddleRight:    "Start",
+	rl.GamepadButtonLeftThumb:      "LS",
+	rl.GamepadButtonRightThumb:     "RS",
+}
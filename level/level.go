@@ -0,0 +1,123 @@
+// Package level describes the static geometry of a scene: how big it is,
+// where the ground is and which ledges can be stood on.
+package level
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// Level is the walkable geometry of one scene.
+type Level struct {
+	Width, Height float32
+	Ground        float32        // y of the ground line everything stands on
+	Platforms     []rl.Rectangle // solid ledges; the top edge is the walkable surface
+	MouseSpawns   []float32      // X positions of the mice living on the ground
+	Doors         []DoorDef      // doors leading to other scenes
+	Containers    []ContainerDef // chests, cabinets, crates
+	Merchants     []MerchantDef  // shopkeepers
+}
+
+// Bounds is the whole of the level, e.g. for keeping the camera inside it.
+func (l *Level) Bounds() rl.Rectangle {
+	return rl.NewRectangle(0, 0, l.Width, l.Height)
+}
+
+// DoorDef places a door in a level. Doors stand on the ground.
+type DoorDef struct {
+	ID     string
+	X      float32 // left edge
+	Key    string  // item that unlocks it ("" = not locked)
+	To     string  // scene the door leads to ("outside", "inside")
+	SpawnX float32 // where the player appears in the destination scene
+}
+
+// ContainerDef places a container on the ground of a level.
+type ContainerDef struct {
+	ID    string // also the key its contents are saved under
+	Name  string
+	X     float32
+	Key   string   // item that unlocks it ("" = not locked)
+	Slots int      // grid size
+	Items []string // starting contents, used until the container is first saved
+}
+
+// MerchantDef places a shopkeeper on the ground of a level.
+type MerchantDef struct {
+	ID   string
+	Name string
+	X    float32
+	Shop string // stock file in assets/shops, without the extension
+}
+
+// Outside is the street level. Fire escapes climb the buildings at both ends
+// of the street, over the spots zombies come in from, so zombies coming in
+// there start up on them and drop down to the street.
+func Outside(worldW, worldH int) *Level {
+	w, g := float32(worldW), float32(worldH)
+	return &Level{
+		Width:  w,
+		Height: g,
+		Ground: g,
+		Platforms: []rl.Rectangle{
+			{X: 0, Y: g - 130, Width: 260, Height: 16},
+			{X: 180, Y: g - 250, Width: 220, Height: 16},
+			{X: w - 260, Y: g - 130, Width: 260, Height: 16},
+			{X: w - 400, Y: g - 250, Width: 220, Height: 16},
+		},
+		MouseSpawns: []float32{450, 1700, 2600, 3300},
+		Doors: []DoorDef{
+			{ID: "HouseFront", X: 1200, Key: "BronzeKey", To: "inside", SpawnX: 1100},
+		},
+		Containers: []ContainerDef{
+			{ID: "street_crate", Name: "Supply Crate", X: 2200, Slots: 5, Items: []string{"HealthPack", "Scrap", "Cloth", "RunningShoes"}},
+		},
+		Merchants: []MerchantDef{
+			{ID: "rosa", Name: "Rosa", X: 600, Shop: "rosa"},
+		},
+	}
+}
+
+// Inside is the interior of the house on the street.
+func Inside(worldW, worldH int) *Level {
+	return &Level{
+		Width:  float32(worldW),
+		Height: float32(worldH),
+		Ground: float32(worldH),
+		Doors: []DoorDef{
+			{ID: "HouseExit", X: 1000, To: "outside", SpawnX: 1320},
+		},
+		Containers: []ContainerDef{
+			{ID: "house_cabinet", Name: "Kitchen Cabinet", X: 1500, Slots: 5, Items: []string{"HealthPack", "HealthPack", "Can", "Powder"}},
+			{ID: "house_locker", Name: "Gun Locker", X: 1800, Key: "BronzeKey", Slots: 5, Items: []string{"Sword", "Helmet"}},
+		},
+		Merchants: []MerchantDef{
+			{ID: "quartermaster", Name: "Quartermaster", X: 600, Shop: "quartermaster"},
+		},
+	}
+}
+
+// Surfaces returns every walkable surface as a rectangle whose top edge is
+// the floor: the ground first, then the platforms.
+func (l *Level) Surfaces() []rl.Rectangle {
+	out := make([]rl.Rectangle, 0, len(l.Platforms)+1)
+	out = append(out, rl.Rectangle{X: 0, Y: l.Ground, Width: l.Width, Height: 1})
+	out = append(out, l.Platforms...)
+	return out
+}
+
+// FloorAt is the highest floor at x: the top of a ledge over x, or the ground.
+func (l *Level) FloorAt(x float32) float32 {
+	floor := l.Ground
+	for _, p := range l.Platforms {
+		if x >= p.X && x <= p.X+p.Width && p.Y < floor {
+			floor = p.Y
+		}
+	}
+	return floor
+}
+
+// DrawPlatforms draws every ledge as a plain block (world space).
+func (l *Level) DrawPlatforms() {
+	for _, p := range l.Platforms {
+		rl.DrawRectangleRec(p, rl.Brown)
+		rl.DrawRectangleLinesEx(p, 2, rl.DarkBrown)
+	}
+}
@@ -0,0 +1,78 @@
+// Package loot rolls weighted drop tables.
+package loot
+
+import (
+	"math/rand"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Rarity is how special a drop is; it only affects how the drop is shown.
+type Rarity int
+
+const (
+	Common Rarity = iota
+	Uncommon
+	Rare
+	Legendary
+)
+
+// Color is the glow drawn under a dropped item of this rarity.
+func (r Rarity) Color() rl.Color {
+	switch r {
+	case Uncommon:
+		return rl.Green
+	case Rare:
+		return rl.SkyBlue
+	case Legendary:
+		return rl.Orange
+	default:
+		return rl.LightGray
+	}
+}
+
+// Entry is one possible drop.
+type Entry struct {
+	Item   string
+	Weight int // relative chance against the other entries and Table.Nothing
+	Rarity Rarity
+}
+
+// Drop is a rolled item.
+type Drop struct {
+	Item   string
+	Rarity Rarity
+}
+
+// Table is what one kind of enemy can drop.
+type Table struct {
+	Rolls      int // how many times Entries are rolled
+	Nothing    int // weight of rolling nothing at all
+	Entries    []Entry
+	Guaranteed []Drop // always dropped, on top of the rolls
+}
+
+// Roll returns the guaranteed drops plus Rolls weighted picks.
+func (t Table) Roll(rnd *rand.Rand) []Drop {
+	drops := append([]Drop(nil), t.Guaranteed...)
+
+	total := t.Nothing
+	for _, e := range t.Entries {
+		total += e.Weight
+	}
+	if total <= 0 {
+		return drops
+	}
+	for i := 0; i < t.Rolls; i++ {
+		roll := rnd.Intn(total)
+		for _, e := range t.Entries {
+			roll -= e.Weight
+			if roll < 0 {
+				drops = append(drops, Drop{Item: e.Item, Rarity: e.Rarity})
+				break
+			}
+		}
+		// falling off the end means the roll landed in Nothing
+	}
+	return drops
+}
@@ -0,0 +1,119 @@
+package loot
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestRoll(t *testing.T) {
+	const tries = 20000
+	sword := Drop{Item: "Sword", Rarity: Legendary}
+	tests := []struct {
+		name  string
+		table Table
+		want  map[string]float64 // average number of each item per Roll
+	}{
+		{
+			name:  "weights",
+			table: Table{Rolls: 1, Entries: []Entry{{Item: "Ammo", Weight: 3}, {Item: "Scrap", Weight: 1}}},
+			want:  map[string]float64{"Ammo": 0.75, "Scrap": 0.25},
+		},
+		{
+			name:  "zero weight never drops",
+			table: Table{Rolls: 1, Entries: []Entry{{Item: "Ammo", Weight: 0}, {Item: "Scrap", Weight: 2}}},
+			want:  map[string]float64{"Scrap": 1},
+		},
+		{
+			name:  "nothing takes its share",
+			table: Table{Rolls: 1, Nothing: 3, Entries: []Entry{{Item: "Ammo", Weight: 1}}},
+			want:  map[string]float64{"Ammo": 0.25},
+		},
+		{
+			name:  "only nothing",
+			table: Table{Rolls: 3, Nothing: 10},
+			want:  map[string]float64{},
+		},
+		{
+			name:  "empty",
+			table: Table{Rolls: 3},
+			want:  map[string]float64{},
+		},
+		{
+			name:  "several rolls",
+			table: Table{Rolls: 3, Nothing: 1, Entries: []Entry{{Item: "Ammo", Weight: 1}}},
+			want:  map[string]float64{"Ammo": 1.5},
+		},
+		{
+			name:  "guaranteed",
+			table: Table{Guaranteed: []Drop{sword}},
+			want:  map[string]float64{"Sword": 1},
+		},
+		{
+			name: "guaranteed on top of the rolls",
+			table: Table{
+				Rolls:      2,
+				Nothing:    100,
+				Entries:    []Entry{{Item: "Ammo", Weight: 1}},
+				Guaranteed: []Drop{sword, {Item: "Ammo", Rarity: Common}},
+			},
+			want: map[string]float64{"Sword": 1, "Ammo": 1 + 2.0/101},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rnd := rand.New(rand.NewSource(1))
+			counts := map[string]int{}
+			for range tries {
+				drops := tt.table.Roll(rnd)
+				if !slices.Equal(drops[:len(tt.table.Guaranteed)], tt.table.Guaranteed) {
+					t.Fatalf("Roll = %v, want it to start with %v", drops, tt.table.Guaranteed)
+				}
+				if most := len(tt.table.Guaranteed) + tt.table.Rolls; len(drops) > most {
+					t.Fatalf("Roll = %v, want at most %d drops", drops, most)
+				}
+				for _, d := range drops {
+					counts[d.Item]++
+				}
+			}
+			for item, n := range counts {
+				if _, ok := tt.want[item]; !ok {
+					t.Errorf("%s dropped %d times, want never", item, n)
+				}
+			}
+			for item, want := range tt.want {
+				if got := float64(counts[item]) / tries; math.Abs(got-want) > 0.02 {
+					t.Errorf("%s dropped %.3f times a roll, want %.3f", item, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRollSeeded(t *testing.T) {
+	table := Table{
+		Rolls:   2,
+		Nothing: 30,
+		Entries: []Entry{
+			{Item: "Ammo", Weight: 30},
+			{Item: "Scrap", Weight: 20, Rarity: Uncommon},
+			{Item: "Sword", Weight: 5, Rarity: Rare},
+		},
+		Guaranteed: []Drop{{Item: "Coin"}},
+	}
+	rolls := func(seed int64) [][]Drop {
+		rnd := rand.New(rand.NewSource(seed))
+		out := make([][]Drop, 50)
+		for i := range out {
+			out[i] = table.Roll(rnd)
+		}
+		return out
+	}
+	a, b := rolls(42), rolls(42)
+	for i := range a {
+		if !slices.Equal(a[i], b[i]) {
+			t.Fatalf("roll %d with seed 42 gave %v, then %v", i, a[i], b[i])
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package nav
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// DrawDebug draws surfaces, nodes and links in world space (call inside BeginMode2D).
+//   - walk links: green, drop links: orange, jump links: sky blue
+func (g *Graph) DrawDebug() {
+	for _, s := range g.Surfaces {
+		rl.DrawLineEx(rl.Vector2{X: s.X1, Y: s.Y}, rl.Vector2{X: s.X2, Y: s.Y}, 3, rl.Fade(rl.Lime, 0.6))
+	}
+	for from, links := range g.Links {
+		a := g.Nodes[from].Pos
+		for _, l := range links {
+			rl.DrawLineV(a, g.Nodes[l.To].Pos, linkColor(l.Kind))
+		}
+	}
+	for _, n := range g.Nodes {
+		rl.DrawCircleV(n.Pos, 4, rl.DarkGreen)
+	}
+}
+
+// DrawPath draws a path starting at from (world space).
+func DrawPath(from rl.Vector2, path []Waypoint) {
+	prev := from
+	for _, wp := range path {
+		rl.DrawLineEx(prev, wp.Pos, 2, linkColor(wp.Kind))
+		rl.DrawCircleV(wp.Pos, 3, rl.Red)
+		prev = wp.Pos
+	}
+}
+
+func linkColor(k LinkKind) rl.Color {
+	switch k {
+	case LinkDrop:
+		return rl.Orange
+	case LinkJump:
+		return rl.SkyBlue
+	default:
+		return rl.Green
+	}
+}
@@ -0,0 +1,211 @@
+// Package nav builds a navigation graph from level geometry and finds paths
+// across it with A*. It only knows about rectangles and points, so it can be
+// built and queried without a window.
+package nav
+
+import (
+	"math"
+	"sort"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// LinkKind says how an agent gets from one node to the next.
+type LinkKind int
+
+const (
+	LinkWalk LinkKind = iota // stroll along the same surface
+	LinkDrop                 // step off an edge and fall
+	LinkJump                 // jump up (or across) to another surface
+)
+
+// Surface is a horizontal walkable span. Y is the floor line.
+type Surface struct {
+	X1, X2, Y float32
+}
+
+// Node is a point on a surface where links start or end.
+type Node struct {
+	Surface int
+	Pos     rl.Vector2 // Pos.Y is the floor line of the surface
+}
+
+// Link is a directed edge of the graph.
+type Link struct {
+	To   int
+	Kind LinkKind
+	Cost float32
+}
+
+// Config limits what the agents using the graph can physically do.
+type Config struct {
+	MaxJumpHeight   float32 // highest ledge an agent can jump onto
+	MaxJumpDistance float32 // widest horizontal gap an agent can jump
+	MaxDropHeight   float32 // furthest an agent is willing to fall
+	EdgeInset       float32 // how far from a surface edge the edge nodes sit
+}
+
+// DefaultConfig suits the 113px zombies.
+func DefaultConfig() Config {
+	return Config{
+		MaxJumpHeight:   140,
+		MaxJumpDistance: 160,
+		MaxDropHeight:   400,
+		EdgeInset:       10,
+	}
+}
+
+// Graph is the navigation graph for one level.
+type Graph struct {
+	Cfg      Config
+	Surfaces []Surface
+	Nodes    []Node
+	Links    [][]Link // Links[i] are the outgoing links of Nodes[i]
+}
+
+// Build turns walkable rectangles (top edge = floor) into a nav graph.
+func Build(rects []rl.Rectangle, cfg Config) *Graph {
+	g := &Graph{Cfg: cfg}
+	for _, r := range rects {
+		g.Surfaces = append(g.Surfaces, Surface{X1: r.X, X2: r.X + r.Width, Y: r.Y})
+	}
+
+	// 1) Two edge nodes per surface
+	for i, s := range g.Surfaces {
+		g.addNode(i, s.X1+cfg.EdgeInset)
+		g.addNode(i, s.X2-cfg.EdgeInset)
+	}
+
+	// 2) Drop and jump links off every edge (only the original edge nodes)
+	edgeCount := len(g.Nodes)
+	for n := 0; n < edgeCount; n++ {
+		top := g.Nodes[n]
+		s := g.Surfaces[top.Surface]
+		leftEdge := n%2 == 0
+		offX := s.X2 + cfg.EdgeInset
+		if leftEdge {
+			offX = s.X1 - cfg.EdgeInset
+		}
+
+		below := g.surfaceBelow(offX, s.Y)
+		if below < 0 {
+			continue
+		}
+		fall := g.Surfaces[below].Y - s.Y
+		landing := g.addNode(below, offX)
+		if fall <= cfg.MaxDropHeight {
+			g.link(n, landing, LinkDrop, fall+20)
+		}
+		if fall <= cfg.MaxJumpHeight {
+			g.link(landing, n, LinkJump, fall*1.5+50)
+		}
+	}
+
+	// 3) Jumps across horizontal gaps between surfaces of similar height
+	for a := 0; a < edgeCount; a++ {
+		for b := 0; b < edgeCount; b++ {
+			na, nb := g.Nodes[a], g.Nodes[b]
+			if na.Surface == nb.Surface {
+				continue
+			}
+			dx := float32(math.Abs(float64(na.Pos.X - nb.Pos.X)))
+			rise := na.Pos.Y - nb.Pos.Y // >0 means b is higher
+			if dx <= cfg.MaxJumpDistance && rise <= cfg.MaxJumpHeight && !g.hasLink(a, b) {
+				g.link(a, b, LinkJump, dx+abs(rise)*1.5+50)
+			}
+		}
+	}
+
+	// 4) Walk links between neighbouring nodes on each surface
+	for i := range g.Surfaces {
+		ids := g.nodesOn(i)
+		for k := 1; k < len(ids); k++ {
+			a, b := ids[k-1], ids[k]
+			d := g.Nodes[b].Pos.X - g.Nodes[a].Pos.X
+			g.link(a, b, LinkWalk, d)
+			g.link(b, a, LinkWalk, d)
+		}
+	}
+	return g
+}
+
+func (g *Graph) addNode(surface int, x float32) int {
+	s := g.Surfaces[surface]
+	x = clamp(x, s.X1, s.X2)
+	g.Nodes = append(g.Nodes, Node{Surface: surface, Pos: rl.Vector2{X: x, Y: s.Y}})
+	g.Links = append(g.Links, nil)
+	return len(g.Nodes) - 1
+}
+
+func (g *Graph) link(from, to int, kind LinkKind, cost float32) {
+	g.Links[from] = append(g.Links[from], Link{To: to, Kind: kind, Cost: cost})
+}
+
+func (g *Graph) hasLink(from, to int) bool {
+	for _, l := range g.Links[from] {
+		if l.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+// nodesOn returns the nodes on a surface sorted left to right.
+func (g *Graph) nodesOn(surface int) []int {
+	var ids []int
+	for i, n := range g.Nodes {
+		if n.Surface == surface {
+			ids = append(ids, i)
+		}
+	}
+	sort.Slice(ids, func(a, b int) bool { return g.Nodes[ids[a]].Pos.X < g.Nodes[ids[b]].Pos.X })
+	return ids
+}
+
+// surfaceBelow finds the highest surface under x whose floor is below y.
+func (g *Graph) surfaceBelow(x, y float32) int {
+	best := -1
+	for i, s := range g.Surfaces {
+		if x < s.X1 || x > s.X2 || s.Y <= y {
+			continue
+		}
+		if best < 0 || s.Y < g.Surfaces[best].Y {
+			best = i
+		}
+	}
+	return best
+}
+
+// SurfaceAt returns the surface an agent with its feet at p is standing on,
+// or the first one below it if it's in the air. -1 if there's nothing under p.
+func (g *Graph) SurfaceAt(p rl.Vector2) int {
+	const tolerance = 4
+	return g.surfaceBelow(p.X, p.Y-tolerance)
+}
+
+// Support returns the floor an agent at x would land on when falling from
+// fromY down to toY, if any.
+func (g *Graph) Support(x, fromY, toY float32) (float32, bool) {
+	i := g.surfaceBelow(x, fromY-1)
+	if i < 0 || g.Surfaces[i].Y > toY {
+		return 0, false
+	}
+	return g.Surfaces[i].Y, true
+}
+
+func clamp(v, lo, hi float32) float32 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+func abs(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
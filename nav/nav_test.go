@@ -0,0 +1,196 @@
+package nav
+
+import (
+	"platformer-game/level"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const ground = 1000
+
+// street is a floor 2000 wide with a ledge low enough to jump onto and one
+// too high to reach from anywhere.
+var street = []rl.Rectangle{
+	{X: 0, Y: ground, Width: 2000, Height: 1},
+	{X: 400, Y: ground - 120, Width: 200, Height: 16},  // low ledge
+	{X: 1200, Y: ground - 400, Width: 200, Height: 16}, // high ledge
+}
+
+func kinds(path []Waypoint) map[LinkKind]int {
+	out := map[LinkKind]int{}
+	for _, wp := range path {
+		out[wp.Kind]++
+	}
+	return out
+}
+
+func TestFindPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		rects    []rl.Rectangle
+		from, to rl.Vector2
+		found    bool
+		jumps    int // how many jump links the path takes
+		drops    int // how many drop links
+	}{
+		{
+			name: "along the ground", rects: street,
+			from: rl.NewVector2(100, ground), to: rl.NewVector2(900, ground),
+			found: true,
+		},
+		{
+			name: "jump up onto a ledge", rects: street,
+			from: rl.NewVector2(200, ground), to: rl.NewVector2(500, ground-120),
+			found: true, jumps: 1,
+		},
+		{
+			name: "drop down off a ledge", rects: street,
+			from: rl.NewVector2(500, ground-120), to: rl.NewVector2(900, ground),
+			found: true, drops: 1,
+		},
+		{
+			name: "drop off the high ledge", rects: street,
+			from: rl.NewVector2(1300, ground-400), to: rl.NewVector2(1800, ground),
+			found: true, drops: 1,
+		},
+		{
+			name: "ledge too high to climb", rects: street,
+			from: rl.NewVector2(1000, ground), to: rl.NewVector2(1300, ground-400),
+			found: false,
+		},
+		{
+			name: "target over nothing", rects: street,
+			from: rl.NewVector2(100, ground), to: rl.NewVector2(2500, ground),
+			found: false,
+		},
+		{
+			name: "jump a gap",
+			rects: []rl.Rectangle{
+				{X: 0, Y: 500, Width: 300, Height: 16},
+				{X: 400, Y: 500, Width: 300, Height: 16},
+			},
+			from: rl.NewVector2(100, 500), to: rl.NewVector2(600, 500),
+			found: true, jumps: 1,
+		},
+		{
+			name: "gap too wide",
+			rects: []rl.Rectangle{
+				{X: 0, Y: 500, Width: 300, Height: 16},
+				{X: 600, Y: 500, Width: 300, Height: 16},
+			},
+			from: rl.NewVector2(100, 500), to: rl.NewVector2(700, 500),
+			found: false,
+		},
+		{
+			name: "up a staircase of ledges",
+			rects: []rl.Rectangle{
+				{X: 0, Y: ground, Width: 2000, Height: 1},
+				{X: 300, Y: ground - 120, Width: 300, Height: 16},
+				{X: 500, Y: ground - 240, Width: 300, Height: 16},
+			},
+			from: rl.NewVector2(100, ground), to: rl.NewVector2(700, ground-240),
+			found: true, jumps: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := Build(tt.rects, DefaultConfig())
+			path := g.FindPath(tt.from, tt.to)
+			if (path != nil) != tt.found {
+				t.Fatalf("found a path: %v, want %v (path %v)", path != nil, tt.found, path)
+			}
+			if !tt.found {
+				return
+			}
+			if end := path[len(path)-1].Pos; end != tt.to {
+				t.Errorf("path ends at %v, want %v", end, tt.to)
+			}
+			k := kinds(path)
+			if k[LinkJump] != tt.jumps || k[LinkDrop] != tt.drops {
+				t.Errorf("path takes %d jumps and %d drops, want %d and %d: %v", k[LinkJump], k[LinkDrop], tt.jumps, tt.drops, path)
+			}
+		})
+	}
+}
+
+func TestBuildLinks(t *testing.T) {
+	g := Build(street, DefaultConfig())
+	if len(g.Surfaces) != len(street) {
+		t.Fatalf("%d surfaces, want %d", len(g.Surfaces), len(street))
+	}
+
+	// Every ledge edge drops to the ground; only the low ledge's landings
+	// jump back up.
+	for n, node := range g.Nodes {
+		if node.Surface == 0 {
+			continue
+		}
+		var drop *Link
+		for i, l := range g.Links[n] {
+			if l.Kind == LinkDrop {
+				drop = &g.Links[n][i]
+			}
+		}
+		if drop == nil {
+			t.Errorf("ledge %d edge at %v has no drop", node.Surface, node.Pos)
+			continue
+		}
+		if g.Nodes[drop.To].Surface != 0 {
+			t.Errorf("ledge %d drops onto surface %d, want the ground", node.Surface, g.Nodes[drop.To].Surface)
+		}
+		jumpsBack := false
+		for _, l := range g.Links[drop.To] {
+			if l.To == n && l.Kind == LinkJump {
+				jumpsBack = true
+			}
+		}
+		if want := node.Surface == 1; jumpsBack != want {
+			t.Errorf("ledge %d: landing jumps back up %v, want %v", node.Surface, jumpsBack, want)
+		}
+	}
+}
+
+func TestSurfaceAtAndSupport(t *testing.T) {
+	g := Build(street, DefaultConfig())
+	tests := []struct {
+		name string
+		p    rl.Vector2
+		want int
+	}{
+		{"on the ground", rl.NewVector2(100, ground), 0},
+		{"on the low ledge", rl.NewVector2(500, ground-120), 1},
+		{"in the air over the ledge", rl.NewVector2(500, ground-300), 1},
+		{"under the ledge", rl.NewVector2(500, ground), 0},
+		{"off the end of the world", rl.NewVector2(2100, ground), -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := g.SurfaceAt(tt.p); got != tt.want {
+				t.Errorf("SurfaceAt(%v) = %d, want %d", tt.p, got, tt.want)
+			}
+		})
+	}
+
+	if y, ok := g.Support(500, ground-200, ground-100); !ok || y != ground-120 {
+		t.Errorf("falling past the low ledge: floor %v, %v; want %v", y, ok, ground-120)
+	}
+	if _, ok := g.Support(800, ground-200, ground-100); ok {
+		t.Error("found a floor in mid air")
+	}
+}
+
+func TestOutsideLevel(t *testing.T) {
+	l := level.Outside(5000, 1200)
+	g := Build(l.Surfaces(), DefaultConfig())
+	for _, x := range []float32{60, 4840} {
+		from := rl.NewVector2(x, l.FloorAt(x))
+		if from.Y == l.Ground {
+			t.Fatalf("no fire escape over x=%v", x)
+		}
+		path := g.FindPath(from, rl.NewVector2(2500, l.Ground))
+		if path == nil || kinds(path)[LinkDrop] == 0 {
+			t.Errorf("from the fire escape at x=%v: path %v, want one dropping to the street", x, path)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package nav
+
+import (
+	"container/heap"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Waypoint is one step of a path: where to go and how to get there from the
+// previous waypoint.
+type Waypoint struct {
+	Pos  rl.Vector2 // Pos.Y is the floor line at that point
+	Kind LinkKind
+}
+
+// FindPath runs A* from the feet position `from` to the feet position `to`.
+// It returns nil when either end isn't over a surface or no route exists.
+// The returned path never includes the start point.
+func (g *Graph) FindPath(from, to rl.Vector2) []Waypoint {
+	startSurf, goalSurf := g.SurfaceAt(from), g.SurfaceAt(to)
+	if startSurf < 0 || goalSurf < 0 {
+		return nil
+	}
+	start, goal := len(g.Nodes), len(g.Nodes)+1
+	startPos := rl.Vector2{X: from.X, Y: g.Surfaces[startSurf].Y}
+	goalPos := rl.Vector2{X: to.X, Y: g.Surfaces[goalSurf].Y}
+
+	pos := func(id int) rl.Vector2 {
+		switch id {
+		case start:
+			return startPos
+		case goal:
+			return goalPos
+		}
+		return g.Nodes[id].Pos
+	}
+	// Outgoing links, including the temporary start/goal nodes
+	neighbours := func(id int) []Link {
+		var out []Link
+		if id == start {
+			for _, n := range g.nodesOn(startSurf) {
+				out = append(out, Link{To: n, Kind: LinkWalk, Cost: abs(g.Nodes[n].Pos.X - from.X)})
+			}
+			if startSurf == goalSurf {
+				out = append(out, Link{To: goal, Kind: LinkWalk, Cost: abs(to.X - from.X)})
+			}
+			return out
+		}
+		if id == goal {
+			return nil
+		}
+		out = append(out, g.Links[id]...)
+		if g.Nodes[id].Surface == goalSurf {
+			out = append(out, Link{To: goal, Kind: LinkWalk, Cost: abs(to.X - g.Nodes[id].Pos.X)})
+		}
+		return out
+	}
+
+	cost := map[int]float32{start: 0}
+	cameFrom := map[int]Link{}
+	prev := map[int]int{}
+	open := &pathQueue{}
+	heap.Push(open, pathItem{id: start, f: rl.Vector2Distance(startPos, goalPos)})
+	closed := map[int]bool{}
+
+	for open.Len() > 0 {
+		cur := heap.Pop(open).(pathItem).id
+		if cur == goal {
+			break
+		}
+		if closed[cur] {
+			continue
+		}
+		closed[cur] = true
+
+		for _, l := range neighbours(cur) {
+			c := cost[cur] + l.Cost
+			if old, seen := cost[l.To]; seen && c >= old {
+				continue
+			}
+			cost[l.To] = c
+			cameFrom[l.To] = l
+			prev[l.To] = cur
+			heap.Push(open, pathItem{id: l.To, f: c + rl.Vector2Distance(pos(l.To), goalPos)})
+		}
+	}
+
+	if _, ok := cost[goal]; !ok {
+		return nil
+	}
+
+	// Walk back from the goal
+	var rev []Waypoint
+	for id := goal; id != start; id = prev[id] {
+		rev = append(rev, Waypoint{Pos: pos(id), Kind: cameFrom[id].Kind})
+	}
+	path := make([]Waypoint, len(rev))
+	for i := range rev {
+		path[i] = rev[len(rev)-1-i]
+	}
+	return path
+}
+
+// ─── priority queue for A* ───
+
+type pathItem struct {
+	id int
+	f  float32
+}
+
+type pathQueue []pathItem
+
+func (q pathQueue) Len() int            { return len(q) }
+func (q pathQueue) Less(i, j int) bool  { return q[i].f < q[j].f }
+func (q pathQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x interface{}) { *q = append(*q, x.(pathItem)) }
+func (q *pathQueue) Pop() interface{} {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
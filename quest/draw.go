@@ -0,0 +1,52 @@
+package quest
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// DrawTracker draws the first active quest and its open objectives at (x, y).
+func (l *Log) DrawTracker(x, y int32) {
+	active := l.Active()
+	if len(active) == 0 {
+		return
+	}
+	q := active[0]
+	rl.DrawText(q.Title, x, y, 14, rl.Gold)
+	y += 18
+	for _, o := range q.Objectives {
+		if o.Done() {
+			continue
+		}
+		rl.DrawText("- "+o.Label(), x+4, y, 12, rl.White)
+		y += 15
+	}
+}
+
+// DrawLog draws the full quest log over a screenW×screenH screen.
+func (l *Log) DrawLog(screenW, screenH int32) {
+	const margin = 40
+	rl.DrawRectangle(margin, margin, screenW-2*margin, screenH-2*margin, rl.Fade(rl.Black, 0.85))
+	rl.DrawRectangleLines(margin, margin, screenW-2*margin, screenH-2*margin, rl.RayWhite)
+	rl.DrawText("Quest Log", margin+15, margin+12, 20, rl.RayWhite)
+
+	y := int32(margin + 45)
+	for _, q := range l.Quests {
+		titleCol := rl.Gold
+		if q.Completed() {
+			titleCol = rl.Gray
+		}
+		rl.DrawText(q.Title, margin+15, y, 16, titleCol)
+		y += 19
+		if q.Description != "" {
+			rl.DrawText(q.Description, margin+25, y, 12, rl.LightGray)
+			y += 15
+		}
+		for _, o := range q.Objectives {
+			mark, col := "[ ]", rl.White
+			if o.Done() {
+				mark, col = "[x]", rl.Gray
+			}
+			rl.DrawText(mark+" "+o.Label(), margin+25, y, 12, col)
+			y += 15
+		}
+		y += 8
+	}
+}
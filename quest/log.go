@@ -0,0 +1,122 @@
+package quest
+
+import (
+	"log"
+	"platformer-game/database"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Log holds every quest the player has.
+type Log struct {
+	Quests []*Quest
+	IsOpen bool // the quest log screen is showing
+
+	// OnComplete, if set, is called once when a quest's last objective is done.
+	OnComplete func(q *Quest)
+}
+
+func NewLog(quests []*Quest) *Log {
+	return &Log{Quests: quests}
+}
+
+// Active returns the quests that still have something left to do.
+func (l *Log) Active() []*Quest {
+	var out []*Quest
+	for _, q := range l.Quests {
+		if !q.Completed() {
+			out = append(out, q)
+		}
+	}
+	return out
+}
+
+// ─── Events ───
+
+// ItemCollected is called when the player picks up an item.
+func (l *Log) ItemCollected(name string) {
+	l.advance(func(o *Objective) bool { return o.Kind == Collect && o.Target == name })
+}
+
+// ZombieKilled is called when a zombie dies; kind is its type name.
+func (l *Log) ZombieKilled(kind string) {
+	l.advance(func(o *Objective) bool { return o.Kind == Kill && (o.Target == "" || o.Target == kind) })
+}
+
+// PlayerAt is called every frame with the player's position.
+func (l *Log) PlayerAt(pos rl.Vector2) {
+	l.advance(func(o *Objective) bool { return o.Kind == Reach && rl.CheckCollisionPointRec(pos, o.Area) })
+}
+
+// DoorOpened is called when a door finishes opening.
+func (l *Log) DoorOpened(id string) {
+	l.advance(func(o *Objective) bool { return o.Kind == OpenDoor && o.Target == id })
+}
+
+// advance bumps every unfinished objective that matches and saves what changed.
+func (l *Log) advance(match func(o *Objective) bool) {
+	for _, q := range l.Quests {
+		if q.Completed() {
+			continue
+		}
+		changed := false
+		for i, o := range q.Objectives {
+			if o.Done() || !match(o) {
+				continue
+			}
+			o.Progress++
+			changed = true
+			l.saveObjective(q, i)
+			if o.Done() {
+				log.Printf("Objective complete: %s\n", o.Text)
+			}
+		}
+		if changed && q.Completed() {
+			log.Printf("Quest complete: %s\n", q.Title)
+			if l.OnComplete != nil {
+				l.OnComplete(q)
+			}
+		}
+	}
+}
+
+// ─── Persistence ───
+
+// LoadFromDB restores saved progress onto the quests in the log.
+func (l *Log) LoadFromDB() {
+	rows, err := database.DB.Query(`SELECT quest_id, objective, progress FROM quests`)
+	if err != nil {
+		log.Println("Failed to load quests from database:", err)
+		return
+	}
+	defer rows.Close()
+
+	byID := map[string]*Quest{}
+	for _, q := range l.Quests {
+		byID[q.ID] = q
+	}
+	for rows.Next() {
+		var id string
+		var idx, progress int
+		if err := rows.Scan(&id, &idx, &progress); err != nil {
+			log.Println("Error scanning quest row:", err)
+			continue
+		}
+		q, ok := byID[id]
+		if !ok || idx < 0 || idx >= len(q.Objectives) {
+			log.Printf("Unknown quest objective %s/%d in database, skipping\n", id, idx)
+			continue
+		}
+		q.Objectives[idx].Progress = progress
+	}
+}
+
+func (l *Log) saveObjective(q *Quest, idx int) {
+	_, err := database.DB.Exec(`
+		INSERT OR REPLACE INTO quests (quest_id, objective, progress)
+		VALUES (?, ?, ?);`,
+		q.ID, idx, q.Objectives[idx].Progress)
+	if err != nil {
+		log.Println("Failed to save quest progress:", err)
+	}
+}
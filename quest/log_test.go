@@ -0,0 +1,164 @@
+package quest
+
+import (
+	"path/filepath"
+	"platformer-game/database"
+	"testing"
+)
+
+// openDB points the database at a fresh file for the test; the log saves
+// progress after every change.
+func openDB(t *testing.T) {
+	t.Helper()
+	database.Path = filepath.Join(t.TempDir(), "test.db")
+	database.InitDatabase()
+	t.Cleanup(func() { database.DB.Close() })
+}
+
+func cleanup() *Quest {
+	return &Quest{
+		ID:    "cleanup",
+		Title: "Street Cleanup",
+		Objectives: []*Objective{
+			{Kind: Kill, Text: "Kill 3 zombies", Count: 3},
+			{Kind: Kill, Text: "Kill a brute", Target: "Brute"},
+		},
+	}
+}
+
+func scavenger() *Quest {
+	return &Quest{
+		ID:    "scavenger",
+		Title: "Scavenger",
+		Objectives: []*Objective{
+			{Kind: Collect, Text: "Find 2 scrap", Target: "Scrap", Count: 2},
+			{Kind: OpenDoor, Text: "Open the shed", Target: "shed"},
+		},
+	}
+}
+
+func TestObjectiveDone(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		progress int
+		needed   int
+		done     bool
+	}{
+		{"no count", 0, 0, 1, false},
+		{"no count, done once", 0, 1, 1, true},
+		{"negative count", -2, 1, 1, true},
+		{"part way", 3, 2, 3, false},
+		{"exactly", 3, 3, 3, true},
+		{"past it", 3, 5, 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Objective{Count: tt.count, Progress: tt.progress}
+			if got := o.Needed(); got != tt.needed {
+				t.Errorf("Needed = %d, want %d", got, tt.needed)
+			}
+			if got := o.Done(); got != tt.done {
+				t.Errorf("Done = %v, want %v", got, tt.done)
+			}
+		})
+	}
+}
+
+func TestZombieKilled(t *testing.T) {
+	openDB(t)
+	tests := []struct {
+		name  string
+		kills []string
+		any   int // progress of "Kill 3 zombies"
+		brute int // progress of "Kill a brute"
+	}{
+		{"any kind counts without a target", []string{"Walker", "Runner"}, 2, 0},
+		{"only the target counts with one", []string{"Walker", "Brute"}, 2, 1},
+		{"done objectives stop counting", []string{"Brute", "Brute", "Walker", "Walker"}, 3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := cleanup()
+			l := NewLog([]*Quest{q})
+			for _, k := range tt.kills {
+				l.ZombieKilled(k)
+			}
+			if got := q.Objectives[0].Progress; got != tt.any {
+				t.Errorf("any-zombie progress = %d, want %d", got, tt.any)
+			}
+			if got := q.Objectives[1].Progress; got != tt.brute {
+				t.Errorf("brute progress = %d, want %d", got, tt.brute)
+			}
+		})
+	}
+}
+
+func TestCompletesOnce(t *testing.T) {
+	openDB(t)
+	c, s := cleanup(), scavenger()
+	l := NewLog([]*Quest{c, s})
+	completed := map[string]int{}
+	l.OnComplete = func(q *Quest) { completed[q.ID]++ }
+
+	for range 5 {
+		l.ZombieKilled("Brute")
+	}
+	l.ItemCollected("Scrap")
+	l.DoorOpened("shed")
+	if completed["cleanup"] != 1 || completed["scavenger"] != 0 {
+		t.Fatalf("completed %v, want only cleanup, once", completed)
+	}
+	if got := l.Active(); len(got) != 1 || got[0] != s {
+		t.Errorf("Active = %v, want just scavenger", got)
+	}
+
+	l.ItemCollected("Scrap")
+	l.ItemCollected("Scrap")
+	l.ZombieKilled("Walker")
+	if completed["cleanup"] != 1 || completed["scavenger"] != 1 {
+		t.Errorf("completed %v, want each quest once", completed)
+	}
+	if got := l.Active(); len(got) != 0 {
+		t.Errorf("Active = %v, want none", got)
+	}
+}
+
+func TestLoadFromDB(t *testing.T) {
+	openDB(t)
+	l := NewLog([]*Quest{cleanup(), scavenger()})
+	l.ZombieKilled("Walker")
+	l.ZombieKilled("Brute")
+	l.ItemCollected("Scrap")
+	if _, err := database.DB.Exec(`INSERT INTO quests (quest_id, objective, progress) VALUES ('gone', 0, 4), ('cleanup', 7, 1)`); err != nil {
+		t.Fatal(err)
+	}
+
+	// A fresh log, as when the game starts again
+	c, s := cleanup(), scavenger()
+	loaded := NewLog([]*Quest{c, s})
+	completed := 0
+	loaded.OnComplete = func(*Quest) { completed++ }
+	loaded.LoadFromDB()
+
+	want := map[*Objective]int{
+		c.Objectives[0]: 2,
+		c.Objectives[1]: 1,
+		s.Objectives[0]: 1,
+		s.Objectives[1]: 0,
+	}
+	for o, p := range want {
+		if o.Progress != p {
+			t.Errorf("%q progress = %d, want %d", o.Text, o.Progress, p)
+		}
+	}
+	if completed != 0 {
+		t.Errorf("loading called OnComplete %d times, want 0", completed)
+	}
+
+	// Progress carries on from where it was saved
+	loaded.ZombieKilled("Walker")
+	if completed != 1 || !c.Completed() {
+		t.Errorf("cleanup completed = %v after %d calls, want it done once", c.Completed(), completed)
+	}
+}
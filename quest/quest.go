@@ -0,0 +1,71 @@
+// Package quest tracks quests and their objectives.
+//
+// The game reports what happens (items picked up, zombies killed, where the
+// player is, doors opened) through the Log's event methods; objectives that
+// match advance, and progress is saved to the quests table after every change.
+package quest
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ObjectiveKind is what has to happen for an objective to advance.
+type ObjectiveKind int
+
+const (
+	Collect  ObjectiveKind = iota // pick up Count items called Target
+	Kill                          // kill Count zombies of type Target ("" = any)
+	Reach                         // walk into Area
+	OpenDoor                      // open the door with ID Target
+)
+
+// Objective is one step of a quest.
+type Objective struct {
+	Kind     ObjectiveKind
+	Text     string
+	Target   string
+	Count    int          // how many times it has to happen (0 is treated as 1)
+	Area     rl.Rectangle // for Reach
+	Progress int
+}
+
+// Needed is how many times the objective has to happen.
+func (o *Objective) Needed() int {
+	if o.Count <= 0 {
+		return 1
+	}
+	return o.Count
+}
+
+// Done reports whether the objective is complete.
+func (o *Objective) Done() bool {
+	return o.Progress >= o.Needed()
+}
+
+// Label is the objective text with a counter when it needs more than one.
+func (o *Objective) Label() string {
+	if o.Needed() > 1 {
+		return fmt.Sprintf("%s (%d/%d)", o.Text, min(o.Progress, o.Needed()), o.Needed())
+	}
+	return o.Text
+}
+
+// Quest is a named set of objectives. It is complete when all of them are.
+type Quest struct {
+	ID          string
+	Title       string
+	Description string
+	Objectives  []*Objective
+}
+
+// Completed reports whether every objective is done.
+func (q *Quest) Completed() bool {
+	for _, o := range q.Objectives {
+		if !o.Done() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package rendering
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// The game is laid out on a virtual screen of this size, whatever the size
+// of the window.
+const (
+	ScreenWidth  = 800
+	ScreenHeight = 450
+)
+
+// Canvas is the virtual screen. Each frame is drawn into it and then scaled
+// to fit the window, with black bars where their shapes differ. The mouse
+// is mapped back, so rl.GetMousePosition reads virtual coordinates.
+type Canvas struct {
+	Integer bool // scale by whole steps only: crisper pixels, wider bars
+
+	target rl.RenderTexture2D
+	dest   rl.Rectangle // where the canvas lands in the window
+	point  bool         // texture filter is set to point (for integer steps)
+}
+
+// NewCanvas makes the render texture; the window must be open.
+func NewCanvas() *Canvas {
+	c := &Canvas{target: rl.LoadRenderTexture(ScreenWidth, ScreenHeight)}
+	rl.SetTextureFilter(c.target.Texture, rl.FilterBilinear)
+	return c
+}
+
+// Layout fits the canvas to the window's current size and maps the mouse to
+// it. Call it at the start of every frame, before the mouse is read.
+func (c *Canvas) Layout() {
+	ww, wh := float32(rl.GetScreenWidth()), float32(rl.GetScreenHeight())
+	scale := min(ww/ScreenWidth, wh/ScreenHeight)
+	if c.Integer && scale >= 1 {
+		scale = float32(math.Floor(float64(scale)))
+	}
+	w, h := ScreenWidth*scale, ScreenHeight*scale
+	c.dest = rl.NewRectangle(float32(math.Floor(float64(ww-w)/2)), float32(math.Floor(float64(wh-h)/2)), w, h)
+
+	rl.SetMouseOffset(-int(c.dest.X), -int(c.dest.Y))
+	rl.SetMouseScale(1/scale, 1/scale)
+
+	if point := c.Integer && scale >= 1; point != c.point {
+		c.point = point
+		filter := rl.FilterBilinear
+		if point {
+			filter = rl.FilterPoint
+		}
+		rl.SetTextureFilter(c.target.Texture, filter)
+	}
+}
+
+// Begin sends drawing to the canvas; everything up to End is in virtual
+// coordinates.
+func (c *Canvas) Begin() {
+	rl.BeginTextureMode(c.target)
+}
+
+func (c *Canvas) End() {
+	rl.EndTextureMode()
+}
+
+// Draw puts the canvas in the window, between BeginDrawing and EndDrawing.
+func (c *Canvas) Draw() {
+	rl.ClearBackground(rl.Black)
+	// Render textures are stored upside down, hence the negative height
+	src := rl.NewRectangle(0, 0, ScreenWidth, -ScreenHeight)
+	rl.DrawTexturePro(c.target.Texture, src, c.dest, rl.Vector2{}, 0, rl.White)
+}
@@ -0,0 +1,16 @@
+package rendering
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// PlaceholderIcon makes a size×size texture filled with fill, outlined and
+// labelled with a few letters, for items that don't have artwork yet.
+func PlaceholderIcon(size int, fill rl.Color, label string) rl.Texture2D {
+	img := rl.GenImageColor(size, size, fill)
+	rl.ImageDrawRectangleLines(img, rl.NewRectangle(0, 0, float32(size), float32(size)), 2, rl.Black)
+	fontSize := int32(size / 3)
+	x := (int32(size) - rl.MeasureText(label, fontSize)) / 2
+	rl.ImageDrawText(img, x, (int32(size)-fontSize)/2, label, fontSize, rl.White)
+	tex := rl.LoadTextureFromImage(img)
+	rl.UnloadImage(img)
+	return tex
+}
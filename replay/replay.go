@@ -0,0 +1,131 @@
+// Package replay records the player's actions tick by tick, together with
+// the seed the run started from, and reads them back so the run can be
+// played again exactly.
+//
+// A replay file is a header (magic, version, seed) followed by one frame per
+// tick: the tick's length in seconds, the actions held down, and the mouse's
+// position and wheel. Frames are written as they happen, so a recording
+// survives the game crashing.
+package replay
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"platformer-game/input"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	magic   = "ZRPL"
+	version = 2 // 2 added the mouse
+)
+
+type header struct {
+	Magic   [4]byte
+	Version uint32
+	Seed    int64
+}
+
+type frame struct {
+	DT             float32
+	Actions        uint32
+	MouseX, MouseY float32
+	Wheel          float32
+}
+
+// Replay is a recorded run.
+type Replay struct {
+	Seed  int64
+	DT    []float32     // length of each tick in seconds
+	Input []input.Frame // actions held down and the mouse during each tick
+}
+
+// Ticks is the number of ticks recorded.
+func (r *Replay) Ticks() int {
+	return len(r.DT)
+}
+
+// Load reads a replay file. A frame cut short at the end (the game died
+// while writing it) is dropped.
+func Load(path string) (*Replay, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	rd := bufio.NewReader(f)
+
+	var h header
+	if err := binary.Read(rd, binary.LittleEndian, &h); err != nil {
+		return nil, fmt.Errorf("%s: reading header: %w", path, err)
+	}
+	if string(h.Magic[:]) != magic {
+		return nil, fmt.Errorf("%s: not a replay file", path)
+	}
+	if h.Version != version {
+		return nil, fmt.Errorf("%s: replay version %d, expected %d", path, h.Version, version)
+	}
+
+	r := &Replay{Seed: h.Seed}
+	for {
+		var fr frame
+		err := binary.Read(rd, binary.LittleEndian, &fr)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return r, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		r.DT = append(r.DT, fr.DT)
+		r.Input = append(r.Input, input.Frame{
+			Actions: input.Set(fr.Actions),
+			Mouse:   rl.NewVector2(fr.MouseX, fr.MouseY),
+			Wheel:   fr.Wheel,
+		})
+	}
+}
+
+// Recorder writes a replay file as the game runs.
+type Recorder struct {
+	f *os.File
+	w *bufio.Writer
+}
+
+// Create starts a replay file for a run started from seed.
+func Create(path string, seed int64) (*Recorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	rec := &Recorder{f: f, w: bufio.NewWriter(f)}
+	h := header{Version: version, Seed: seed}
+	copy(h.Magic[:], magic)
+	if err := binary.Write(rec.w, binary.LittleEndian, h); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return rec, rec.w.Flush()
+}
+
+// Tick records one tick.
+func (rec *Recorder) Tick(dt float32, in input.Frame) error {
+	fr := frame{dt, uint32(in.Actions), in.Mouse.X, in.Mouse.Y, in.Wheel}
+	if err := binary.Write(rec.w, binary.LittleEndian, fr); err != nil {
+		return err
+	}
+	return rec.w.Flush()
+}
+
+// Close finishes the file.
+func (rec *Recorder) Close() error {
+	if err := rec.w.Flush(); err != nil {
+		rec.f.Close()
+		return err
+	}
+	return rec.f.Close()
+}
@@ -0,0 +1,239 @@
+package replay
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"platformer-game/dialogue"
+	"platformer-game/input"
+	"slices"
+	"strings"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func held(actions ...input.Action) input.Set {
+	var s input.Set
+	for _, a := range actions {
+		s = s.With(a)
+	}
+	return s
+}
+
+// record writes a replay of frames, one tick each, and returns its path.
+func record(t *testing.T, seed int64, dt []float32, frames []input.Frame) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "run.rpl")
+	rec, err := Create(path, seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, f := range frames {
+		if err := rec.Tick(dt[i], f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+var (
+	someDT     = []float32{1.0 / 60, 1.0 / 30, 0.25}
+	someFrames = []input.Frame{
+		{Actions: held(input.MoveRight, input.Run)},
+		{Actions: held(input.Click), Mouse: rl.NewVector2(412.5, 96), Wheel: -1},
+		{Mouse: rl.NewVector2(-3, 800)},
+	}
+)
+
+func TestRoundTrip(t *testing.T) {
+	r, err := Load(record(t, -8675309, someDT, someFrames))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Seed != -8675309 {
+		t.Errorf("seed %d, want -8675309", r.Seed)
+	}
+	if r.Ticks() != len(someFrames) || !slices.Equal(r.DT, someDT) || !slices.Equal(r.Input, someFrames) {
+		t.Errorf("read back %d ticks %v %+v, want %v %+v", r.Ticks(), r.DT, r.Input, someDT, someFrames)
+	}
+}
+
+func TestLoadCutShort(t *testing.T) {
+	path := record(t, 1, someDT, someFrames)
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(path, info.Size()-3); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(r.Input, someFrames[:2]) {
+		t.Errorf("read back %+v, want the two whole frames", r.Input)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, data any) string {
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if err := binary.Write(f, binary.LittleEndian, data); err != nil {
+			t.Fatal(err)
+		}
+		return f.Name()
+	}
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"missing", filepath.Join(dir, "nope.rpl"), "no such file"},
+		{"too short", write("short.rpl", []byte("ZR")), "reading header"},
+		{"not a replay", write("save.rpl", header{Magic: [4]byte{'S', 'A', 'V', 'E'}, Version: version}), "not a replay file"},
+		{"old version", write("v1.rpl", header{Magic: [4]byte{'Z', 'R', 'P', 'L'}, Version: 1}), "version 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Load(tt.path)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load = %v, %v; want an error mentioning %q", r, err, tt.want)
+			}
+		})
+	}
+}
+
+// world is the game as far as a conversation can tell.
+type world struct {
+	items    map[string]bool
+	unlocked []string
+}
+
+func (w *world) HasItem(name string) bool { return w.items[name] }
+func (w *world) GiveItem(name string)     { w.items[name] = true }
+func (w *world) TakeItem(name string)     { delete(w.items, name) }
+func (w *world) UnlockDoor(id string)     { w.unlocked = append(w.unlocked, id) }
+
+var deal = &dialogue.Script{
+	ID:    "deal",
+	Start: "ask",
+	Nodes: map[string]*dialogue.Node{
+		"ask": {
+			Speaker: "Sam", Text: "Got a coin for the key?",
+			Choices: []dialogue.Choice{
+				{Text: "Here.", If: []dialogue.Condition{{HasItem: "Coin"}}, Next: "thanks", Actions: []dialogue.Action{
+					{TakeItem: "Coin"}, {GiveItem: "Key"}, {SetFlag: "paid"},
+				}},
+				{Text: "Later.", Actions: []dialogue.Action{{SetFlag: "stalled"}}},
+				{Text: "Never.", Actions: []dialogue.Action{{SetFlag: "refused"}}},
+			},
+		},
+		"thanks": {Speaker: "Sam", Text: "It opens the back door.", Actions: []dialogue.Action{{UnlockDoor: "back"}}},
+	},
+}
+
+// outcome is where a conversation ended up.
+type outcome struct {
+	active   bool
+	flags    []string
+	items    []string
+	unlocked []string
+}
+
+// converse plays deal with the given ticks of input, as the game does: each
+// tick reads the input, records it if rec is set, then updates.
+func converse(t *testing.T, src input.Source, dt []float32, rec *Recorder) outcome {
+	t.Helper()
+	input.SetSource(src)
+	t.Cleanup(func() { input.SetSource(nil) })
+
+	w := &world{items: map[string]bool{"Coin": true}}
+	r := dialogue.NewRunner(w)
+	r.Start(deal)
+	for _, d := range dt {
+		input.Update()
+		if rec != nil {
+			if err := rec.Tick(d, input.Current()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		r.Update(d)
+	}
+
+	o := outcome{active: r.Active(), unlocked: w.unlocked}
+	for f := range r.Flags {
+		o.flags = append(o.flags, f)
+	}
+	for it := range w.items {
+		o.items = append(o.items, it)
+	}
+	slices.Sort(o.flags)
+	slices.Sort(o.items)
+	return o
+}
+
+func (o outcome) equal(p outcome) bool {
+	return o.active == p.active && slices.Equal(o.flags, p.flags) &&
+		slices.Equal(o.items, p.items) && slices.Equal(o.unlocked, p.unlocked)
+}
+
+// TestScriptedRun records a scripted conversation, plays the file back and
+// checks both runs end up in the same place: the player moves the choice
+// cursor about, pays with the first choice and reads the reply.
+func TestScriptedRun(t *testing.T) {
+	frames := []input.Frame{
+		{}, {}, {}, // the line types out
+		{Actions: held(input.MenuDown)},
+		{},
+		{Actions: held(input.MenuDown)},
+		{Mouse: rl.NewVector2(300, 200)}, // the mouse wanders; nothing here reads it
+		{Actions: held(input.MenuUp), Mouse: rl.NewVector2(310, 200)},
+		{Actions: held(input.MenuUp)}, // held, so it doesn't move again
+		{},
+		{Actions: held(input.Slot1)}, // pay
+		{},
+		{Actions: held(input.Confirm)}, // finish typing the reply...
+		{},
+		{Actions: held(input.Confirm)}, // ...and close it
+		{},
+	}
+	dt := make([]float32, len(frames))
+	for i := range dt {
+		dt[i] = []float32{0.25, 0.2, 0.3}[i%3]
+	}
+	want := outcome{flags: []string{"paid"}, items: []string{"Key"}, unlocked: []string{"back"}}
+
+	path := filepath.Join(t.TempDir(), "deal.rpl")
+	rec, err := Create(path, 77)
+	if err != nil {
+		t.Fatal(err)
+	}
+	live := converse(t, &input.Script{Frames: frames}, dt, rec)
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !live.equal(want) {
+		t.Fatalf("recorded run ended %+v, want %+v", live, want)
+	}
+
+	r, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Seed != 77 || r.Ticks() != len(frames) {
+		t.Fatalf("replay has seed %d and %d ticks, want 77 and %d", r.Seed, r.Ticks(), len(frames))
+	}
+	if replayed := converse(t, &input.Script{Frames: r.Input}, r.DT, nil); !replayed.equal(live) {
+		t.Errorf("replay ended %+v, the recorded run %+v", replayed, live)
+	}
+}
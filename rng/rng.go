@@ -0,0 +1,71 @@
+// Package rng is the game's own randomness. Everything random in a run draws
+// from one of a few streams, all grown from a single seed, so the same seed
+// and the same input play out the same run. Each subsystem has a stream of
+// its own: an extra loot roll doesn't change where the next zombie spawns.
+package rng
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Stream is a subsystem's share of the randomness.
+type Stream int
+
+const (
+	Spawning Stream = iota // which zombies come in a wave, and where
+	Loot                   // what zombies drop
+	AI                     // zombie brains and the mice
+	numStreams
+)
+
+var (
+	seed    int64
+	streams [numStreams]*rand.Rand
+)
+
+func init() {
+	Reseed(NewSeed())
+}
+
+// NewSeed picks a seed from the clock, for a run that wasn't given one.
+func NewSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// Reseed restarts every stream from s.
+func Reseed(s int64) {
+	seed = s
+	for i := range streams {
+		streams[i] = rand.New(rand.NewSource(mix(s, i)))
+	}
+}
+
+// Derive is the seed for attempt n at a run started from base: the run
+// played again after a reload or a death rolls differently, but the same way
+// every time. Attempt 0 is base itself.
+func Derive(base int64, n int) int64 {
+	if n == 0 {
+		return base
+	}
+	return mix(base, int(numStreams)+n) // past the streams' own, so no attempt shares a seed with one
+}
+
+// Seed is the seed the streams were last started from.
+func Seed() int64 {
+	return seed
+}
+
+// Get returns a subsystem's stream.
+func Get(s Stream) *rand.Rand {
+	return streams[s]
+}
+
+// mix derives stream i's seed from the run's (one splitmix64 step), so the
+// streams don't start out in step with each other.
+func mix(s int64, i int) int64 {
+	z := uint64(s) + uint64(i+1)*0x9E3779B97F4A7C15
+	z = (z ^ z>>30) * 0xBF58476D1CE4E5B9
+	z = (z ^ z>>27) * 0x94D049BB133111EB
+	return int64(z ^ z>>31)
+}
@@ -0,0 +1,95 @@
+package rng
+
+import "testing"
+
+// draws takes n numbers from a stream.
+func draws(s Stream, n int) []int64 {
+	out := make([]int64, n)
+	for i := range out {
+		out[i] = Get(s).Int63()
+	}
+	return out
+}
+
+func same(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestReproducible(t *testing.T) {
+	for _, s := range []Stream{Spawning, Loot, AI} {
+		Reseed(42)
+		a := draws(s, 20)
+		Reseed(42)
+		b := draws(s, 20)
+		if !same(a, b) {
+			t.Errorf("stream %d: the same seed drew %v, then %v", s, a, b)
+		}
+		Reseed(43)
+		if same(a, draws(s, 20)) {
+			t.Errorf("stream %d: seeds 42 and 43 drew the same numbers", s)
+		}
+	}
+	if Seed() != 43 {
+		t.Errorf("Seed() = %d, want 43", Seed())
+	}
+}
+
+func TestStreamsIndependent(t *testing.T) {
+	Reseed(7)
+	spawns := draws(Spawning, 10)
+	ai := draws(AI, 10)
+
+	// Extra loot rolls in between don't shift the other streams
+	Reseed(7)
+	draws(Loot, 100)
+	if got := draws(Spawning, 10); !same(got, spawns) {
+		t.Errorf("loot rolls changed the spawns: %v, want %v", got, spawns)
+	}
+	draws(Loot, 3)
+	if got := draws(AI, 10); !same(got, ai) {
+		t.Errorf("loot rolls changed the AI: %v, want %v", got, ai)
+	}
+
+	Reseed(7)
+	if a, b, c := draws(Spawning, 10), draws(Loot, 10), draws(AI, 10); same(a, b) || same(b, c) || same(a, c) {
+		t.Error("two streams drew the same numbers")
+	}
+}
+
+func TestDerive(t *testing.T) {
+	if got := Derive(1234, 0); got != 1234 {
+		t.Errorf("Derive(1234, 0) = %d, want the seed itself", got)
+	}
+	seen := map[int64]int{}
+	for n := range 50 {
+		s := Derive(1234, n)
+		if s != Derive(1234, n) {
+			t.Fatalf("attempt %d derived two different seeds", n)
+		}
+		if m, ok := seen[s]; ok {
+			t.Fatalf("attempts %d and %d share seed %d", m, n, s)
+		}
+		seen[s] = n
+	}
+	for i := range int(numStreams) {
+		if _, ok := seen[mix(1234, i)]; ok {
+			t.Errorf("an attempt shares its seed with stream %d", i)
+		}
+	}
+
+	// Attempts play out differently
+	Reseed(Derive(1234, 1))
+	first := draws(Loot, 10)
+	Reseed(Derive(1234, 2))
+	if same(first, draws(Loot, 10)) {
+		t.Error("attempts 1 and 2 rolled the same loot")
+	}
+}
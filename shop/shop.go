@@ -0,0 +1,198 @@
+// Package shop runs merchants: stock loaded from data, prices, restocking,
+// and saving what's left on the shelves.
+package shop
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"platformer-game/database"
+	"strings"
+)
+
+// Entry is one item a merchant sells.
+type Entry struct {
+	Item     string `json:"item"`
+	Price    int    `json:"price"` // base price of one
+	Max      int    `json:"max"`   // most the shelf holds; restocking fills up to it
+	Quantity int    `json:"-"`     // on the shelf right now
+}
+
+// Shop is a merchant's stock and how they set prices.
+type Shop struct {
+	ID          string   `json:"-"` // file name without extension
+	Name        string   `json:"name"`
+	Markup      float32  `json:"markup"`       // multiplies every buy price
+	Scarcity    float32  `json:"scarcity"`     // extra markup when a shelf is empty (0.5 = +50%)
+	SellRate    float32  `json:"sell_rate"`    // fraction of an item's value the merchant pays
+	RestockTime float32  `json:"restock_time"` // seconds between restocks of one of each item
+	Stock       []*Entry `json:"stock"`
+
+	restockLeft float32 // seconds until the next restock
+}
+
+// LoadShops reads every *.json file in dir. Broken files are logged and skipped.
+func LoadShops(dir string) map[string]*Shop {
+	shops := map[string]*Shop{}
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		log.Println("Failed to list shops:", err)
+		return shops
+	}
+	for _, f := range files {
+		s, err := Load(f)
+		if err != nil {
+			log.Println("Failed to load shop:", err)
+			continue
+		}
+		shops[s.ID] = s
+	}
+	return shops
+}
+
+// Load reads one shop file. Shelves start full.
+func Load(path string) (*Shop, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Shop
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	s.ID = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if s.Markup <= 0 {
+		s.Markup = 1
+	}
+	if s.SellRate <= 0 {
+		s.SellRate = 0.5
+	}
+	for _, e := range s.Stock {
+		if e.Item == "" || e.Price <= 0 || e.Max <= 0 {
+			return nil, fmt.Errorf("%s: stock entry %q needs an item, a price and a max", path, e.Item)
+		}
+		e.Quantity = e.Max
+	}
+	s.restockLeft = s.RestockTime
+	return &s, nil
+}
+
+// Entry returns the stock entry for item, or nil if the shop doesn't sell it.
+func (s *Shop) Entry(item string) *Entry {
+	for _, e := range s.Stock {
+		if e.Item == item {
+			return e
+		}
+	}
+	return nil
+}
+
+// BuyPrice is what one of e costs: the base price times the markup, and
+// dearer the emptier the shelf.
+func (s *Shop) BuyPrice(e *Entry) int {
+	empty := min(max(1-float32(e.Quantity)/float32(e.Max), 0), 1)
+	p := float32(e.Price) * s.Markup * (1 + s.Scarcity*empty)
+	return int(math.Ceil(float64(p)))
+}
+
+// SellPrice is what the merchant pays for one item worth value, in the given
+// condition (1 = new, for gear that wears down). Items the shop stocks are
+// valued at its own base price.
+func (s *Shop) SellPrice(item string, value int, condition float32) int {
+	if e := s.Entry(item); e != nil {
+		value = e.Price
+	}
+	return int(float32(value) * s.SellRate * condition)
+}
+
+// Take removes one of e from the shelf.
+func (s *Shop) Take(e *Entry) {
+	if e.Quantity > 0 {
+		e.Quantity--
+	}
+	s.SaveToDB()
+}
+
+// Put adds an item sold by the player to the shelf, if the shop stocks it
+// and the shelf isn't already full.
+func (s *Shop) Put(item string) {
+	if e := s.Entry(item); e != nil && e.Quantity < e.Max {
+		e.Quantity++
+	}
+	s.SaveToDB()
+}
+
+// Update counts down to the next restock, which puts one of every item back
+// (up to its max). Returns true if anything was restocked.
+func (s *Shop) Update(dt float32) bool {
+	if s.RestockTime <= 0 {
+		return false
+	}
+	s.restockLeft -= dt
+	if s.restockLeft > 0 {
+		return false
+	}
+	s.restockLeft = s.RestockTime
+	changed := false
+	for _, e := range s.Stock {
+		if e.Quantity < e.Max {
+			e.Quantity++
+			changed = true
+		}
+	}
+	s.SaveToDB()
+	return changed
+}
+
+// ─── Persistence ───
+
+// LoadFromDB restores the shelves and restock timer saved for this shop, if any.
+func (s *Shop) LoadFromDB() {
+	row := database.DB.QueryRow(`SELECT restock_left FROM shops WHERE shop_id = ?`, s.ID)
+	if err := row.Scan(&s.restockLeft); err != nil {
+		return // never saved: keep the full shelves from the data file
+	}
+
+	rows, err := database.DB.Query(`SELECT item, quantity FROM shop_stock WHERE shop_id = ?`, s.ID)
+	if err != nil {
+		log.Println("Failed to load shop stock from database:", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item string
+		var qty int
+		if err := rows.Scan(&item, &qty); err != nil {
+			log.Println("Error scanning shop stock row:", err)
+			continue
+		}
+		if e := s.Entry(item); e != nil {
+			e.Quantity = qty
+		}
+	}
+}
+
+// SaveToDB writes the shelves and the restock timer.
+func (s *Shop) SaveToDB() {
+	_, err := database.DB.Exec(`
+		INSERT OR REPLACE INTO shops (shop_id, restock_left)
+		VALUES (?, ?);`,
+		s.ID, s.restockLeft)
+	if err != nil {
+		log.Println("Failed to save shop:", err)
+		return
+	}
+	for _, e := range s.Stock {
+		_, err := database.DB.Exec(`
+			INSERT OR REPLACE INTO shop_stock (shop_id, item, quantity)
+			VALUES (?, ?, ?);`,
+			s.ID, e.Item, e.Quantity)
+		if err != nil {
+			log.Println("Failed to save shop stock:", err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package shop
+
+import (
+	"path/filepath"
+	"platformer-game/database"
+	"testing"
+)
+
+// openDB points the database at a fresh file for the test; Put, Take and
+// Update save the shelves as they change them.
+func openDB(t *testing.T) {
+	t.Helper()
+	database.Path = filepath.Join(t.TempDir(), "test.db")
+	database.InitDatabase()
+	t.Cleanup(func() { database.DB.Close() })
+}
+
+func quartermaster() *Shop {
+	return &Shop{
+		ID:          "quartermaster",
+		Name:        "Quartermaster",
+		Markup:      1.5,
+		Scarcity:    1,
+		SellRate:    0.6,
+		RestockTime: 10,
+		restockLeft: 10,
+		Stock: []*Entry{
+			{Item: "Helmet", Price: 30, Max: 1, Quantity: 1},
+			{Item: "Bandage", Price: 10, Max: 4, Quantity: 4},
+		},
+	}
+}
+
+func TestBuyPrice(t *testing.T) {
+	s := quartermaster()
+	helmet, bandage := s.Entry("Helmet"), s.Entry("Bandage")
+	tests := []struct {
+		name string
+		e    *Entry
+		qty  int
+		want int
+	}{
+		{"full shelf", bandage, 4, 15},
+		{"half empty", bandage, 2, 23}, // 15 * 1.5 = 22.5, rounded up
+		{"empty shelf", helmet, 0, 90},
+		{"overfull shelf", helmet, 2, 45},
+		{"negative quantity", helmet, -1, 90},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.Quantity = tt.qty
+			if got := s.BuyPrice(tt.e); got != tt.want {
+				t.Errorf("BuyPrice with %d of %d = %d, want %d", tt.qty, tt.e.Max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSellPrice(t *testing.T) {
+	s := quartermaster()
+	tests := []struct {
+		name      string
+		item      string
+		value     int
+		condition float32
+		want      int
+	}{
+		{"stocked item uses the shop's price", "Helmet", 5, 1, 18},
+		{"worn gear sells for less", "Helmet", 5, 0.5, 9},
+		{"unstocked item uses its value", "Rope", 20, 1, 12},
+		{"worthless item", "Rock", 0, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.SellPrice(tt.item, tt.value, tt.condition); got != tt.want {
+				t.Errorf("SellPrice(%q, %d, %v) = %d, want %d", tt.item, tt.value, tt.condition, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPutStopsAtMax(t *testing.T) {
+	openDB(t)
+	s := quartermaster()
+	helmet := s.Entry("Helmet")
+	full := s.BuyPrice(helmet)
+
+	s.Put("Helmet")
+	if helmet.Quantity != helmet.Max {
+		t.Fatalf("Put on a full shelf: quantity %d, want %d", helmet.Quantity, helmet.Max)
+	}
+	if got := s.BuyPrice(helmet); got != full {
+		t.Errorf("selling to a full shelf changed the buy price from %d to %d", full, got)
+	}
+
+	s.Take(helmet)
+	s.Put("Helmet")
+	if helmet.Quantity != 1 {
+		t.Errorf("Put after Take: quantity %d, want 1", helmet.Quantity)
+	}
+
+	s.Put("Rope") // not stocked: nothing to put it on
+	if s.Entry("Rope") != nil {
+		t.Error("Put added an entry for an item the shop doesn't stock")
+	}
+}
+
+func TestUpdateRestocks(t *testing.T) {
+	openDB(t)
+	s := quartermaster()
+	helmet, bandage := s.Entry("Helmet"), s.Entry("Bandage")
+	helmet.Quantity, bandage.Quantity = 0, 2
+
+	if s.Update(9) {
+		t.Fatal("restocked before the restock time")
+	}
+	if !s.Update(1) {
+		t.Fatal("didn't restock at the restock time")
+	}
+	if helmet.Quantity != 1 || bandage.Quantity != 3 {
+		t.Fatalf("after one restock: helmet %d, bandage %d; want 1, 3", helmet.Quantity, bandage.Quantity)
+	}
+
+	s.Update(10)
+	if helmet.Quantity != 1 || bandage.Quantity != 4 {
+		t.Fatalf("after two restocks: helmet %d, bandage %d; want 1, 4", helmet.Quantity, bandage.Quantity)
+	}
+	if s.Update(10) {
+		t.Error("reported a restock with every shelf full")
+	}
+
+	s.RestockTime = 0
+	bandage.Quantity = 0
+	if s.Update(100) || bandage.Quantity != 0 {
+		t.Error("restocked a shop that never restocks")
+	}
+}
@@ -0,0 +1,40 @@
+package ui
+
+// Anchor is the point of the screen a HUD element hangs from, so it keeps its
+// place against an edge or the middle whatever the screen's size.
+type Anchor int
+
+const (
+	TopLeft Anchor = iota
+	TopCenter
+	TopRight
+	CenterLeft
+	Center
+	CenterRight
+	BottomLeft
+	BottomCenter
+	BottomRight
+)
+
+// Place returns the top-left corner of a w×h box hung from anchor a of a
+// screenW×screenH screen, kept marginX and marginY in from the edges it
+// touches.
+func Place(a Anchor, w, h, marginX, marginY, screenW, screenH int32) (x, y int32) {
+	switch a % 3 {
+	case 0: // left
+		x = marginX
+	case 1: // centre
+		x = (screenW - w) / 2
+	case 2: // right
+		x = screenW - w - marginX
+	}
+	switch a / 3 {
+	case 0: // top
+		y = marginY
+	case 1: // middle
+		y = (screenH - h) / 2
+	case 2: // bottom
+		y = screenH - h - marginY
+	}
+	return x, y
+}
@@ -0,0 +1,146 @@
+// Package ui draws the menus shown outside of play: the title screen, the
+// pause menu and the settings. A Menu is a column of buttons that works with
+// the keyboard, a gamepad or the mouse.
+package ui
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Layout of a menu, centred on the screen
+const (
+	rowW      = 300
+	rowH      = 28
+	rowGap    = 4
+	titleSize = 32
+	labelSize = 16
+)
+
+// Item is one button of a menu.
+type Item struct {
+	Label    string
+	Value    string // drawn on the right, e.g. a setting's current value; "" for plain buttons
+	Disabled bool   // drawn greyed out and can't be selected
+}
+
+// Menu is a titled column of buttons.
+type Menu struct {
+	Title    string
+	Items    []Item
+	Selected int
+}
+
+// rowRect is the screen rectangle of item i on a screenW×screenH screen.
+// Long menus squeeze their rows to leave room for the title and hint.
+func (m *Menu) rowRect(i int, screenW, screenH int32) rl.Rectangle {
+	step := int32(rowH + rowGap)
+	if fit := (screenH - 120) / int32(len(m.Items)); fit < step {
+		step = fit
+	}
+	total := int32(len(m.Items))*step - rowGap
+	y := (screenH-total)/2 + titleSize/2 + int32(i)*step
+	return rl.NewRectangle(float32((screenW-rowW)/2), float32(y), rowW, float32(step-rowGap))
+}
+
+// Update moves the selection with Up/Down, the d-pad or the mouse and reports
+// what the player did this frame: chosen is the item picked with Enter, the
+// gamepad's A button or a click (-1 for none), and step is -1/+1 when
+// Left/Right was pressed on the selected item (0 for none).
+func (m *Menu) Update(screenW, screenH int32) (chosen, step int) {
+	chosen = -1
+	if len(m.Items) == 0 {
+		return
+	}
+	if m.Selected < 0 || m.Selected >= len(m.Items) || m.Items[m.Selected].Disabled {
+		m.move(1)
+	}
+
+	switch {
+	case rl.IsKeyPressed(rl.KeyUp) || rl.IsGamepadButtonPressed(0, rl.GamepadButtonLeftFaceUp):
+		m.move(-1)
+	case rl.IsKeyPressed(rl.KeyDown) || rl.IsGamepadButtonPressed(0, rl.GamepadButtonLeftFaceDown):
+		m.move(1)
+	case rl.IsKeyPressed(rl.KeyLeft) || rl.IsGamepadButtonPressed(0, rl.GamepadButtonLeftFaceLeft):
+		step = -1
+	case rl.IsKeyPressed(rl.KeyRight) || rl.IsGamepadButtonPressed(0, rl.GamepadButtonLeftFaceRight):
+		step = 1
+	case rl.IsKeyPressed(rl.KeyEnter) || rl.IsGamepadButtonPressed(0, rl.GamepadButtonRightFaceDown):
+		chosen = m.Selected
+	}
+
+	mouse := rl.GetMousePosition()
+	for i, it := range m.Items {
+		if it.Disabled || !rl.CheckCollisionPointRec(mouse, m.rowRect(i, screenW, screenH)) {
+			continue
+		}
+		if d := rl.GetMouseDelta(); d.X != 0 || d.Y != 0 {
+			m.Selected = i
+		}
+		if rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+			m.Selected = i
+			chosen = i
+		}
+	}
+	if chosen >= 0 && m.Items[chosen].Disabled {
+		chosen = -1
+	}
+	return
+}
+
+// move steps the selection by dir, skipping disabled items.
+func (m *Menu) move(dir int) {
+	n := len(m.Items)
+	for tries := 0; tries < n; tries++ {
+		m.Selected = (m.Selected + dir + n) % n
+		if !m.Items[m.Selected].Disabled {
+			return
+		}
+	}
+}
+
+// Back reports whether the player asked to leave the current menu (ESC or
+// the gamepad's B button).
+func Back() bool {
+	return rl.IsKeyPressed(rl.KeyEscape) || rl.IsGamepadButtonPressed(0, rl.GamepadButtonRightFaceRight)
+}
+
+// Draw draws the menu centred on a screenW×screenH screen.
+func (m *Menu) Draw(screenW, screenH int32) {
+	if len(m.Items) > 0 {
+		first := m.rowRect(0, screenW, screenH)
+		tw := rl.MeasureText(m.Title, titleSize)
+		rl.DrawText(m.Title, (screenW-tw)/2, int32(first.Y)-titleSize-20, titleSize, rl.RayWhite)
+	}
+
+	for i, it := range m.Items {
+		r := m.rowRect(i, screenW, screenH)
+		bg, fg := rl.Fade(rl.DarkGray, 0.8), rl.White
+		switch {
+		case it.Disabled:
+			fg = rl.Gray
+		case i == m.Selected:
+			bg = rl.Fade(rl.Maroon, 0.9)
+		}
+		rl.DrawRectangleRec(r, bg)
+		if i == m.Selected && !it.Disabled {
+			rl.DrawRectangleLinesEx(r, 2, rl.Gold)
+		}
+		ty := int32(r.Y) + (int32(r.Height)-labelSize)/2
+		rl.DrawText(it.Label, int32(r.X)+12, ty, labelSize, fg)
+		if it.Value != "" {
+			vw := rl.MeasureText(it.Value, labelSize)
+			rl.DrawText(it.Value, int32(r.X+r.Width)-vw-12, ty, labelSize, rl.Gold)
+		}
+	}
+}
+
+// Hint draws a line of help text along the bottom of the screen.
+func Hint(text string, screenW, screenH int32) {
+	w := rl.MeasureText(text, 14)
+	rl.DrawText(text, (screenW-w)/2, screenH-30, 14, rl.LightGray)
+}
+
+// Dim darkens everything drawn so far, so a menu stands out over the game.
+func Dim(screenW, screenH int32) {
+	rl.DrawRectangle(0, 0, screenW, screenH, rl.Fade(rl.Black, 0.6))
+}